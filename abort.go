@@ -0,0 +1,57 @@
+package retryables
+
+import (
+	"context"
+	"sync"
+)
+
+// SetAbortAttemptSignal sets a channel that aborts the attempt in progress without stopping Retry:
+// every value received from it cancels the context of the current attempt, and the retryer treats the
+// attempt as a retryable failure, whatever its error and the condition func, then backs off and makes
+// the next attempt. Closing the channel aborts every attempt. Cancelling the context of the call, by
+// contrast, stops the whole Retry. RetryMeta and RetryHTTP leave the decision to their caller instead.
+//
+// Aborting relies on the function honouring its context, so it takes effect with RetryCtx (and the other
+// entry points passing an attempt context), not with functions that ignore it, and an attempt only counts
+// as aborted if the function returns an error matching context.Canceled; an attempt that completes despite
+// the signal keeps its own result. Values sent while no attempt is in progress are dropped when the next
+// attempt starts, so they never abort it.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetAbortAttemptSignal(signal <-chan struct{}) {
+	r.abortAttempt = signal
+}
+
+// drain drops the values sent to signal while no attempt was in progress.
+func drain(signal <-chan struct{}) {
+	for {
+		select {
+		case _, ok := <-signal:
+			if !ok {
+				return // a closed signal aborts every attempt
+			}
+		default:
+			return
+		}
+	}
+}
+
+// abortable derives a context from ctx that is cancelled when signal fires.
+// stop releases the context and reports whether it was cancelled by the signal; it may be called again.
+func abortable(ctx context.Context, signal <-chan struct{}) (_ context.Context, stop func() bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	aborted := make(chan bool, 1)
+	go func() {
+		select {
+		case <-signal:
+			cancel()
+			aborted <- true
+		case <-ctx.Done():
+			aborted <- false
+		}
+	}()
+
+	return ctx, sync.OnceValue(func() bool {
+		cancel()
+		return <-aborted
+	})
+}
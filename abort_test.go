@@ -0,0 +1,117 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_AbortAttemptSignal(t *testing.T) {
+	abort := make(chan struct{})
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetClock(newFakeClock())
+	retryer.SetAbortAttemptSignal(abort)
+	// aborted attempts are retried even though the condition func rejects their error
+	retryer.SetConditionFunc(func(err error) bool {
+		return !errors.Is(err, context.Canceled)
+	})
+
+	started := make(chan struct{})
+	go func() {
+		<-started
+		abort <- struct{}{}
+	}()
+
+	attempts := 0
+	err := retryer.RetryCtx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			close(started)
+			select {
+			case <-ctx.Done(): // hangs until aborted
+				return ctx.Err()
+			case <-time.After(10 * time.Second):
+				return errors.New("attempt was not aborted")
+			}
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryer_AbortAttemptSignal_NotAborted(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetAbortAttemptSignal(make(chan struct{}))
+	retryer.SetConditionFunc(func(err error) bool {
+		return !errors.Is(err, context.Canceled)
+	})
+
+	attempts := 0
+	err := retryer.RetryCtx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return context.Canceled
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryer_AbortAttemptSignal_BetweenAttempts(t *testing.T) {
+	abort := make(chan struct{}, 1)
+	abort <- struct{}{} // sent before the call, while no attempt is in progress
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetClock(newFakeClock())
+	retryer.SetAbortAttemptSignal(abort)
+
+	attempts := 0
+	err := retryer.RetryCtx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		}
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.Empty(t, abort)
+}
+
+func TestRetryer_AbortAttemptSignal_AfterFailure(t *testing.T) {
+	abort := make(chan struct{})
+	errBadRequest := errors.New("bad request")
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetClock(newFakeClock())
+	retryer.SetAbortAttemptSignal(abort)
+	retryer.SetConditionFunc(func(err error) bool {
+		return !errors.Is(err, errBadRequest)
+	})
+
+	attempts := 0
+	err := retryer.RetryCtx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		// the signal cancels the attempt, but it fails with its own non-retryable error anyway
+		abort <- struct{}{}
+		<-ctx.Done()
+		return errBadRequest
+	})
+
+	assert.ErrorIs(t, err, errBadRequest)
+	assert.Equal(t, 1, attempts)
+}
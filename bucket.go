@@ -0,0 +1,61 @@
+package retryables
+
+import (
+	"sync"
+	"time"
+)
+
+// leakyBucket spaces out the retries of all concurrent calls of a retryer.
+type leakyBucket struct {
+	mu   sync.Mutex
+	next time.Time // earliest time the next retry may be made
+}
+
+// booking is a retry slot reserved in a leakyBucket.
+type booking struct {
+	slot time.Time // when the retry may be made
+	prev time.Time // next of the bucket before the slot was booked
+}
+
+// reserve books a retry no earlier than now+sleep. Unless stopAt is zero, nothing is booked and ok is false
+// if the slot would not be before stopAt, so calls that give up leave the bucket to the others.
+func (b *leakyBucket) reserve(now time.Time, sleep, interval time.Duration, stopAt time.Time) (booking, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	slot := now.Add(sleep)
+	if slot.Before(b.next) {
+		slot = b.next
+	}
+	if !stopAt.IsZero() && !slot.Before(stopAt) {
+		return booking{}, false
+	}
+	bk := booking{slot: slot, prev: b.next}
+	b.next = slot.Add(interval)
+	return bk, true
+}
+
+// release gives back bk, for a retry that will not be made, unless a later slot was booked since.
+func (b *leakyBucket) release(bk booking, interval time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.next.Equal(bk.slot.Add(interval)) {
+		b.next = bk.prev
+	}
+}
+
+// SetGlobalRetryRate caps the aggregate rate of retries made by all concurrent Retry calls of the retryer
+// at perSecond. Retries are spaced evenly through a leaky bucket: a call sleeps the longer of its own backoff
+// and the time until the next free slot, which prevents synchronized bursts of retries against a struggling
+// dependency. First attempts are not throttled. A retry that is given up on, because its slot would come at or
+// after the stop time or the context is done while waiting for it, is not booked or gives its slot back.
+// Zero or a negative rate disables the cap.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetGlobalRetryRate(perSecond int) {
+	if perSecond <= 0 {
+		r.globalRetryInterval = 0
+		return
+	}
+	r.globalRetryInterval = time.Second / time.Duration(perSecond)
+}
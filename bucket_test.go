@@ -0,0 +1,132 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_GlobalRetryRate(t *testing.T) {
+	const (
+		rate       = 20
+		goroutines = 5
+		attempts   = 4
+	)
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(attempts)
+	retryer.SetDelay(0, 0)
+	retryer.SetGlobalRetryRate(rate)
+
+	var (
+		mu      sync.Mutex
+		retries []time.Time
+		wg      sync.WaitGroup
+	)
+	start := time.Now()
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attempt := 0
+			_ = retryer.Retry(context.Background(), func() error {
+				attempt++
+				if attempt > 1 {
+					mu.Lock()
+					retries = append(retries, time.Now())
+					mu.Unlock()
+				}
+				return errors.New("some error")
+			})
+		}()
+	}
+	wg.Wait()
+
+	total := goroutines * (attempts - 1)
+	assert.Len(t, retries, total)
+	assert.GreaterOrEqual(t, time.Since(start), time.Duration(total-1)*time.Second/rate)
+
+	sort.Slice(retries, func(i, j int) bool { return retries[i].Before(retries[j]) })
+	for i, from := range retries {
+		inWindow := 0
+		for _, retry := range retries[i:] {
+			if retry.Sub(from) < time.Second/2 {
+				inWindow++
+			}
+		}
+		// +2 tolerates late timer wake-ups
+		assert.LessOrEqual(t, inWindow, rate/2+2)
+	}
+}
+
+// blockingClock never fires After once blocked, so a call sleeps until its context is done.
+type blockingClock struct {
+	*fakeClock
+	blocked atomic.Bool
+}
+
+func (c *blockingClock) After(d time.Duration) <-chan time.Time {
+	if c.blocked.Load() {
+		return make(chan time.Time)
+	}
+	return c.fakeClock.After(d)
+}
+
+func TestRetryer_GlobalRetryRateGiveUp(t *testing.T) {
+	tests := []struct {
+		name   string
+		giveUp func(r *retryables.Retryer, clock *blockingClock)
+	}{
+		{
+			name: "Budget exhausted",
+			giveUp: func(r *retryables.Retryer, clock *blockingClock) {
+				_ = r.RetryStopAt(context.Background(), clock.Now().Add(500*time.Millisecond), func() error {
+					return errors.New("unavailable")
+				})
+			},
+		},
+		{
+			name: "Context cancelled",
+			giveUp: func(r *retryables.Retryer, clock *blockingClock) {
+				ctx, cancel := context.WithCancel(context.Background())
+				r.SetOnRetry(func(retryables.RetryInfo) { cancel() })
+				defer r.SetOnRetry(nil)
+
+				clock.blocked.Store(true)
+				defer clock.blocked.Store(false)
+				_ = r.Retry(ctx, func() error {
+					return errors.New("unavailable")
+				})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := &blockingClock{fakeClock: newFakeClock()}
+			retryer := retryables.NewRetryer(nil)
+			retryer.SetCount(2)
+			retryer.SetDelay(time.Second, time.Second)
+			retryer.SetJitter(retryables.JitterNone)
+			retryer.SetClock(clock)
+			retryer.SetRecordSchedule(true)
+			retryer.SetGlobalRetryRate(1)
+
+			test.giveUp(retryer, clock)
+
+			// the retry that was given up on does not hold a slot
+			_ = retryer.Retry(context.Background(), func() error {
+				return errors.New("unavailable")
+			})
+			assert.Equal(t, []time.Duration{time.Second}, retryer.LastSchedule())
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package retryables
+
+import "time"
+
+// Clock is the source of time used by Retryer for measuring elapsed time and sleeping between attempts.
+// Tests can inject a fake implementation via SetClock to avoid wall-clock waits.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SetClock sets the clock used by Retry. Passing nil restores the real clock.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetClock(clock Clock) {
+	if clock == nil {
+		clock = realClock{}
+	}
+	r.clock = clock
+}
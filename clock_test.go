@@ -0,0 +1,224 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+// fakeClock advances instantly on After, so retries never wait on the wall clock.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestRetryer_LastSchedule(t *testing.T) {
+	const seed = 7
+
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(5)
+	retryer.SetDelay(100*time.Millisecond, time.Second)
+	retryer.SetClock(clock)
+	retryer.SetRand(rand.New(rand.NewSource(seed)))
+	retryer.SetRecordSchedule(true)
+
+	start := clock.Now()
+	err := retryer.Retry(context.Background(), func() error {
+		return errors.New("permanent error")
+	})
+	assert.Error(t, err)
+
+	expected := make([]time.Duration, 0, 4)
+	var total time.Duration
+	ref := rand.New(rand.NewSource(seed))
+	for attempt := 0; attempt < 4; attempt++ {
+		sleep := time.Duration(ref.Int63n(int64(retryer.ComputeBackoff(attempt))))
+		expected = append(expected, sleep)
+		total += sleep
+	}
+
+	assert.Equal(t, expected, retryer.LastSchedule())
+	assert.Equal(t, total, clock.Now().Sub(start))
+}
+
+func TestRetryer_ComputeBackoff(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetDelay(100*time.Millisecond, time.Second)
+
+	assert.Equal(t, 100*time.Millisecond, retryer.ComputeBackoff(0))
+	assert.Equal(t, 200*time.Millisecond, retryer.ComputeBackoff(1))
+	assert.Equal(t, 800*time.Millisecond, retryer.ComputeBackoff(3))
+	assert.Equal(t, time.Second, retryer.ComputeBackoff(4))
+}
+
+func TestRetryer_LastSchedule_Disabled(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(2)
+	retryer.SetClock(newFakeClock())
+	_ = retryer.Retry(context.Background(), func() error { return errors.New("error") })
+
+	assert.Empty(t, retryer.LastSchedule())
+}
+
+func TestRetryer_ComputeBackoff_Monotonic(t *testing.T) {
+	delays := []struct {
+		base time.Duration
+		max  time.Duration
+	}{
+		{base: time.Millisecond, max: time.Hour},
+		{base: 3 * time.Nanosecond, max: math.MaxInt64},
+		{base: 7 * time.Second, max: math.MaxInt64 - 1},
+	}
+
+	for _, delay := range delays {
+		retryer := retryables.NewRetryer(nil)
+		retryer.SetDelay(delay.base, delay.max)
+
+		prev := retryer.ComputeBackoff(0)
+		assert.Equal(t, delay.base, prev)
+		for attempt := 1; attempt < 200; attempt++ {
+			backoff := retryer.ComputeBackoff(attempt)
+			assert.GreaterOrEqual(t, backoff, prev, "attempt %d", attempt)
+			assert.LessOrEqual(t, backoff, delay.max, "attempt %d", attempt)
+			prev = backoff
+		}
+		assert.Equal(t, delay.max, prev)
+	}
+}
+
+func TestRetryer_DeterministicJitter(t *testing.T) {
+	const seed = 11
+
+	run := func() []time.Duration {
+		retryer := retryables.NewRetryer(nil)
+		retryer.SetCount(6)
+		retryer.SetDelay(time.Second, time.Minute)
+		retryer.SetClock(newFakeClock())
+		retryer.SetRecordSchedule(true)
+		retryer.SetDeterministicJitter(seed)
+
+		_ = retryer.Retry(context.Background(), func() error {
+			return errors.New("permanent error")
+		})
+		return retryer.LastSchedule()
+	}
+
+	first, second := run(), run()
+	assert.Equal(t, first, second)
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetDelay(time.Second, time.Minute)
+	for attempt, sleep := range first {
+		expect := rand.New(rand.NewSource(seed + int64(attempt))).Int63n(int64(retryer.ComputeBackoff(attempt)))
+		assert.Equal(t, time.Duration(expect), sleep)
+	}
+}
+
+func TestRetryer_MaxSingleSleep(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(8)
+	retryer.SetDelay(10*time.Millisecond, time.Minute)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(newFakeClock())
+	retryer.SetRecordSchedule(true)
+	retryer.SetMaxSingleSleep(50 * time.Millisecond)
+
+	_ = retryer.Retry(context.Background(), func() error {
+		return errors.New("permanent error")
+	})
+
+	assert.Equal(t, []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+	}, retryer.LastSchedule())
+}
+
+func TestRetryer_RelativeMaxDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDelay time.Duration
+		latency  time.Duration
+		expect   []time.Duration
+	}{
+		{
+			name:     "Below the absolute cap",
+			maxDelay: time.Minute,
+			latency:  100 * time.Millisecond,
+			expect:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			name:     "Above the absolute cap",
+			maxDelay: 150 * time.Millisecond,
+			latency:  100 * time.Millisecond,
+			expect:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			name:     "Instant first attempt",
+			maxDelay: 150 * time.Millisecond,
+			expect:   []time.Duration{100 * time.Millisecond, 150 * time.Millisecond, 150 * time.Millisecond, 150 * time.Millisecond},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := newFakeClock()
+			retryer := retryables.NewRetryer(nil)
+			retryer.SetCount(5)
+			retryer.SetDelay(100*time.Millisecond, test.maxDelay)
+			retryer.SetJitter(retryables.JitterNone)
+			retryer.SetClock(clock)
+			retryer.SetRecordSchedule(true)
+			retryer.SetRelativeMaxDelay(3)
+
+			calls := 0
+			_ = retryer.Retry(context.Background(), func() error {
+				calls++
+				if calls == 1 {
+					clock.Advance(test.latency)
+				} else {
+					clock.Advance(time.Second) // later attempts don't move the cap
+				}
+				return errors.New("unavailable")
+			})
+
+			assert.Equal(t, test.expect, retryer.LastSchedule())
+		})
+	}
+}
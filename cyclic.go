@@ -0,0 +1,28 @@
+package retryables
+
+import "time"
+
+// SetCyclicDelays replaces the exponential backoff with an explicit schedule of sleeps, e.g.
+// 1s, 2s, 5s, for long-lived loops such as reconnecting to a server. Once the schedule is exhausted,
+// the last delay repeats, or the whole schedule starts over if repeatWhole is set. Sleeps are not jittered.
+//
+// With cyclic delays the attempt count set via SetCount is ignored: Retry keeps trying until the function
+// succeeds, returns a non-retryable error, the context is done or the max elapsed budget (see SetMaxElapsed)
+// runs out, so make sure one of those eventually happens. As the loop may run for a long time, it keeps only
+// the error of the latest attempt, unless RetryWithResult or the summary log (see SetSummaryLog) reports
+// every error, in which case memory grows with the number of failed attempts.
+// An empty schedule restores the regular backoff.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetCyclicDelays(delays []time.Duration, repeatWhole bool) {
+	r.cyclicDelays = append([]time.Duration(nil), delays...)
+	r.cycleWholeDelays = repeatWhole
+}
+
+// cyclicDelay returns the sleep number i (zero-based) of the cyclic schedule.
+func (r *Retryer) cyclicDelay(i int) time.Duration {
+	n := len(r.cyclicDelays)
+	if r.cycleWholeDelays {
+		return r.cyclicDelays[i%n]
+	}
+	return r.cyclicDelays[min(i, n-1)]
+}
@@ -0,0 +1,134 @@
+package retryables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_CyclicDelays(t *testing.T) {
+	delays := []time.Duration{time.Second, 2 * time.Second, 5 * time.Second}
+
+	tests := []struct {
+		name        string
+		repeatWhole bool
+		expect      []time.Duration
+	}{
+		{
+			name:   "Repeat last",
+			expect: []time.Duration{time.Second, 2 * time.Second, 5 * time.Second, 5 * time.Second, 5 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			name:        "Repeat whole",
+			repeatWhole: true,
+			expect:      []time.Duration{time.Second, 2 * time.Second, 5 * time.Second, time.Second, 2 * time.Second, 5 * time.Second, time.Second},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			retryer := retryables.NewRetryer(nil)
+			retryer.SetCount(2) // ignored with cyclic delays
+			retryer.SetClock(newFakeClock())
+			retryer.SetRecordSchedule(true)
+			retryer.SetCyclicDelays(delays, test.repeatWhole)
+
+			attempts := 0
+			err := retryer.Retry(context.Background(), func() error {
+				attempts++
+				if attempts < 8 {
+					return errors.New("connection refused")
+				}
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, 8, attempts)
+			assert.Equal(t, test.expect, retryer.LastSchedule())
+		})
+	}
+}
+
+func TestRetryer_CyclicDelays_StopsOnBudget(t *testing.T) {
+	var logBuffer bytes.Buffer
+
+	retryer := retryables.NewRetryer(&logBuffer)
+	retryer.SetClock(newFakeClock())
+	retryer.SetCyclicDelays([]time.Duration{time.Second}, false)
+	retryer.SetMaxElapsed(10 * time.Second)
+
+	attempts := 0
+	err := retryer.Retry(context.Background(), func() error {
+		attempts++
+		return errors.New("connection refused")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 10, attempts)
+	assert.Contains(t, logBuffer.String(), "Attempt 10 failed: connection refused\n")
+}
+
+func TestRetryer_CyclicDelays_Errors(t *testing.T) {
+	newRetryer := func() *retryables.Retryer {
+		retryer := retryables.NewRetryer(nil)
+		retryer.SetClock(newFakeClock())
+		retryer.SetCyclicDelays([]time.Duration{time.Second}, false)
+		return retryer
+	}
+	failing := func(attempts *int, until int) retryables.RetryableFunc {
+		return func() error {
+			*attempts++
+			if *attempts < until {
+				return fmt.Errorf("attempt %d refused", *attempts)
+			}
+			return nil
+		}
+	}
+
+	t.Run("Latest error", func(t *testing.T) {
+		retryer := newRetryer()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var lastErr error
+		retryer.SetOnCancel(func(_ context.Context, err error) {
+			lastErr = err
+		})
+		attempts := 0
+		_ = retryer.Retry(ctx, func() error {
+			attempts++
+			if attempts == 1000 {
+				cancel()
+			}
+			return fmt.Errorf("attempt %d refused", attempts)
+		})
+		assert.EqualError(t, lastErr, "attempt 1000 refused")
+	})
+
+	t.Run("Counts every failure", func(t *testing.T) {
+		retryer := newRetryer()
+		retryer.SetConditionFunc(func(error) bool { return false })
+		retryer.SetGraceAttempts(3)
+
+		attempts := 0
+		err := retryer.Retry(context.Background(), failing(&attempts, 100))
+		assert.EqualError(t, err, "attempt 4 refused")
+		assert.Equal(t, 4, attempts)
+	})
+
+	t.Run("Result keeps every error", func(t *testing.T) {
+		retryer := newRetryer()
+
+		attempts := 0
+		result, err := retryer.RetryWithResult(context.Background(), failing(&attempts, 4))
+		assert.NoError(t, err)
+		assert.Len(t, result.Errors, 3)
+	})
+}
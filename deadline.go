@@ -0,0 +1,56 @@
+package retryables
+
+import (
+	"context"
+	"time"
+)
+
+// SetDeadlineFit switches the backoff to a schedule that fits the remaining attempts into the deadline
+// of the context passed to Retry. Instead of growing from baseDelay, the sleeps form a geometric series
+// with ratio 2 whose sum is the time left before the deadline, less the average duration of an attempt
+// reserved for the last one. The series is recomputed before every sleep, so time spent in attempts
+// is accounted for. Sleeps are not jittered nor capped by maxDelay in this mode.
+// The deadline is the earlier of the context deadline and the stop time set by SetMaxElapsed, RetryStopAt
+// or SetDeadlineResolver; calls with neither use the regular backoff.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetDeadlineFit(enabled bool) {
+	r.deadlineFit = enabled
+}
+
+// SetDeadlineResolver sets how the overall deadline of a Retry call is determined, e.g. the earliest of
+// the context deadline, a hint from the server and a configured maximum. The resolver is called once with
+// the context passed to Retry before the first attempt; if it reports a deadline, no retry is scheduled
+// whose sleep would end at or after it, and the error of the latest attempt is returned, as with
+// SetMaxElapsed. The context still bounds the call and the attempts themselves.
+// By default, or when passed nil, the deadline is the one of the context, enforced by the context itself.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetDeadlineResolver(resolver func(ctx context.Context) (time.Time, bool)) {
+	r.deadlineResolver = resolver
+}
+
+// fitDelay returns the first term of the geometric series that fits the sleeps left after the given
+// failed attempt into the time left before the deadline of ctx or the stop time, whichever comes first.
+func (r *Retryer) fitDelay(ctx context.Context, inv *invocation, attempt int) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !inv.stopAt.IsZero() && (!ok || !deadline.Before(inv.stopAt)) {
+		// no sleep may end at the stop time itself, so the series must end just before it
+		deadline, ok = inv.stopAt.Add(-time.Nanosecond), true
+	}
+	if !ok {
+		return 0, false
+	}
+
+	remaining := deadline.Sub(r.clock.Now())
+	if inv.attempts > 0 {
+		remaining -= inv.busy / time.Duration(inv.attempts)
+	}
+	if remaining <= 0 {
+		return 0, true
+	}
+
+	sleeps := min(inv.maxAttempts-attempt-1, 62)
+	if sleeps < 1 {
+		return 0, true
+	}
+	return remaining / time.Duration(int64(1)<<sleeps-1), true
+}
@@ -0,0 +1,156 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_DeadlineFit(t *testing.T) {
+	const budget = 14 * time.Second
+
+	now := time.Now()
+	clock := &fakeClock{now: now}
+	ctx, cancel := context.WithDeadline(context.Background(), now.Add(budget))
+	defer cancel()
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(4)
+	retryer.SetClock(clock)
+	retryer.SetRecordSchedule(true)
+	retryer.SetDeadlineFit(true)
+
+	err := retryer.Retry(ctx, func() error {
+		return errors.New("permanent error")
+	})
+	assert.Error(t, err)
+
+	schedule := retryer.LastSchedule()
+	if assert.Len(t, schedule, 3) {
+		var total time.Duration
+		for i, sleep := range schedule {
+			if i > 0 {
+				assert.InDelta(t, 2*schedule[i-1], sleep, float64(time.Millisecond), "sleeps grow geometrically")
+			}
+			total += sleep
+		}
+		assert.InDelta(t, budget, total, float64(time.Millisecond))
+	}
+}
+
+func TestRetryer_DeadlineFit_AttemptDuration(t *testing.T) {
+	const budget = 10 * time.Second
+
+	now := time.Now()
+	clock := &fakeClock{now: now}
+	ctx, cancel := context.WithDeadline(context.Background(), now.Add(budget))
+	defer cancel()
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetClock(clock)
+	retryer.SetDeadlineFit(true)
+
+	var starts []time.Time
+	err := retryer.Retry(ctx, func() error {
+		starts = append(starts, clock.Now())
+		clock.Advance(time.Second)
+		return errors.New("permanent error")
+	})
+	assert.Error(t, err)
+
+	if assert.Len(t, starts, 3) {
+		// the last attempt starts one average attempt duration before the deadline
+		assert.InDelta(t, budget-time.Second, starts[2].Sub(now), float64(time.Millisecond))
+	}
+}
+
+func TestRetryer_DeadlineFit_NoDeadline(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetDelay(time.Second, time.Second)
+	retryer.SetClock(newFakeClock())
+	retryer.SetRecordSchedule(true)
+	retryer.SetDeadlineFit(true)
+
+	_ = retryer.Retry(context.Background(), func() error {
+		return errors.New("permanent error")
+	})
+
+	for _, sleep := range retryer.LastSchedule() {
+		assert.Less(t, sleep, time.Second)
+	}
+}
+
+func TestRetryer_DeadlineFit_MaxElapsed(t *testing.T) {
+	const budget = 700 * time.Millisecond
+
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(4)
+	retryer.SetClock(clock)
+	retryer.SetRecordSchedule(true)
+	retryer.SetDeadlineFit(true)
+	retryer.SetMaxElapsed(budget)
+
+	attempts := 0
+	result, err := retryer.RetryWithResult(context.Background(), func() error {
+		attempts++
+		return errors.New("unavailable")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 4, attempts)
+	assert.Equal(t, retryables.ExitExhausted, result.ExitReason)
+
+	schedule := retryer.LastSchedule()
+	if assert.Len(t, schedule, 3) {
+		var total time.Duration
+		for _, sleep := range schedule {
+			total += sleep
+		}
+		assert.Less(t, total, budget)
+		assert.InDelta(t, budget, total, float64(time.Millisecond))
+		assert.InDelta(t, 100*time.Millisecond, schedule[0], float64(time.Millisecond))
+	}
+}
+
+func TestRetryer_DeadlineResolver(t *testing.T) {
+	now := time.Now()
+	clock := &fakeClock{now: now}
+	ctx, cancel := context.WithDeadline(context.Background(), now.Add(time.Hour))
+	defer cancel()
+
+	serverHint := now.Add(time.Second)
+	var resolvedCtx context.Context
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(10)
+	retryer.SetDelay(400*time.Millisecond, time.Minute)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(clock)
+	retryer.SetRecordSchedule(true)
+	retryer.SetDeadlineResolver(func(ctx context.Context) (time.Time, bool) {
+		resolvedCtx = ctx
+		deadline, ok := ctx.Deadline()
+		if !ok || serverHint.Before(deadline) {
+			return serverHint, true
+		}
+		return deadline, true
+	})
+
+	errUnavailable := errors.New("unavailable")
+	result, err := retryer.RetryWithResult(ctx, func() error {
+		return errUnavailable
+	})
+
+	assert.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, ctx, resolvedCtx)
+	// 400ms fits before the hint, 800ms more would end past it
+	assert.Equal(t, []time.Duration{400 * time.Millisecond}, retryer.LastSchedule())
+	assert.Equal(t, 2, result.Attempts)
+	assert.Equal(t, retryables.ExitBudgetExhausted, result.ExitReason)
+}
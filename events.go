@@ -0,0 +1,90 @@
+package retryables
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Kinds of the events written by a retryer created with NewJSONRetryer.
+const (
+	eventAttempt         = "attempt"          // an attempt is about to be made
+	eventFailure         = "failure"          // an attempt failed
+	eventPrecheckFailure = "precheck_failure" // the precheck before an attempt failed
+	eventBackoff         = "backoff"          // the retryer is about to sleep before the next attempt
+	eventGiveUp          = "give_up"          // the call returned an error
+	eventSuccess         = "success"          // the call succeeded
+)
+
+// event is a lifecycle event of a Retry call, written as one JSON object per line.
+type event struct {
+	Time        time.Time      `json:"time"`
+	Event       string         `json:"event"`
+	Name        string         `json:"name,omitempty"`
+	Attempt     int            `json:"attempt"`
+	MaxAttempts int            `json:"max_attempts,omitempty"`
+	Err         error          `json:"-"`
+	Error       string         `json:"error,omitempty"`
+	Delay       time.Duration  `json:"-"`
+	DelayMS     float64        `json:"delay_ms,omitempty"`
+	Elapsed     time.Duration  `json:"-"`
+	ElapsedMS   float64        `json:"elapsed_ms,omitempty"`
+	Fields      map[string]any `json:"fields,omitempty"`
+}
+
+// NewJSONRetryer returns a Retryer that writes every lifecycle event of its Retry calls to w as
+// newline-delimited JSON, ready for ingestion by log shippers, instead of plain log lines.
+// Each line is an object with the keys:
+//
+//	time          RFC 3339 timestamp
+//	event         attempt, failure, precheck_failure, backoff, give_up or success
+//	name          name of the operation, see SetName (omitted if unset)
+//	attempt       one-based attempt number; for give_up and success, the number of attempts made
+//	max_attempts  attempt count, see SetCount (omitted when unlimited, see SetCyclicDelays)
+//	error         error of a failure, precheck_failure or give_up
+//	delay_ms      sleep announced by a backoff, in milliseconds
+//	elapsed_ms    duration of the call for give_up and success, in milliseconds
+//	fields        fields passed to RetryWithFields (omitted if none)
+func NewJSONRetryer(w io.Writer) *Retryer {
+	r := NewRetryer(w)
+	r.jsonEvents = true
+	return r
+}
+
+// emit writes ev for inv if the retryer emits JSON events.
+func (r *Retryer) emit(inv *invocation, ev event) {
+	if !r.jsonEvents {
+		return
+	}
+
+	ev.Time = r.clock.Now()
+	ev.Name = r.name
+	if inv.maxAttempts != unlimitedAttempts {
+		ev.MaxAttempts = inv.maxAttempts
+	}
+	if ev.Err != nil {
+		ev.Error = ev.Err.Error()
+	}
+	ev.DelayMS = durationMS(ev.Delay)
+	ev.ElapsedMS = durationMS(ev.Elapsed)
+	ev.Fields = inv.call.fields
+
+	line, err := json.Marshal(ev)
+	if err != nil {
+		// some field is not representable in JSON, fall back to its text form
+		fields := make(map[string]any, len(ev.Fields))
+		for key, value := range ev.Fields {
+			fields[key] = fmt.Sprint(value)
+		}
+		ev.Fields = fields
+		if line, err = json.Marshal(ev); err != nil {
+			return
+		}
+	}
+	_, _ = r.logger.Write(append(line, '\n'))
+}
+
+func durationMS(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,102 @@
+package retryables_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestNewJSONRetryer(t *testing.T) {
+	var logBuffer bytes.Buffer
+
+	retryer := retryables.NewJSONRetryer(&logBuffer)
+	retryer.SetCount(3)
+	retryer.SetDelay(time.Second, time.Second)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(newFakeClock())
+	retryer.SetName("fetch")
+	retryer.SetSummaryLog(true)
+
+	attempts := 0
+	err := retryer.RetryWithFields(context.Background(), map[string]any{"request_id": "abc-1"}, func() error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("some error")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	type record struct {
+		Time        time.Time      `json:"time"`
+		Event       string         `json:"event"`
+		Name        string         `json:"name"`
+		Attempt     int            `json:"attempt"`
+		MaxAttempts int            `json:"max_attempts"`
+		Error       string         `json:"error"`
+		DelayMS     float64        `json:"delay_ms"`
+		ElapsedMS   float64        `json:"elapsed_ms"`
+		Fields      map[string]any `json:"fields"`
+	}
+
+	var records []record
+	scanner := bufio.NewScanner(&logBuffer)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		require.True(t, json.Valid(line), "invalid JSON line: %s", line)
+
+		var rec record
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		require.NoError(t, decoder.Decode(&rec))
+		records = append(records, rec)
+	}
+
+	events := make([]string, 0, len(records))
+	for _, rec := range records {
+		events = append(events, rec.Event)
+		assert.False(t, rec.Time.IsZero())
+		assert.Equal(t, "fetch", rec.Name)
+		assert.Equal(t, 3, rec.MaxAttempts)
+		assert.Equal(t, map[string]any{"request_id": "abc-1"}, rec.Fields)
+	}
+	assert.Equal(t, []string{"attempt", "failure", "backoff", "attempt", "success"}, events)
+
+	if assert.Len(t, records, 5) {
+		assert.Equal(t, 1, records[1].Attempt)
+		assert.Equal(t, "some error", records[1].Error)
+		assert.Equal(t, 1000.0, records[2].DelayMS)
+		assert.Equal(t, 2, records[3].Attempt)
+		assert.Equal(t, 2, records[4].Attempt)
+		assert.Equal(t, 1000.0, records[4].ElapsedMS)
+	}
+}
+
+func TestNewJSONRetryer_GiveUp(t *testing.T) {
+	var logBuffer bytes.Buffer
+
+	retryer := retryables.NewJSONRetryer(&logBuffer)
+	retryer.SetCount(1)
+
+	_ = retryer.Retry(context.Background(), func() error {
+		return errors.New("permanent error")
+	})
+
+	lines := bytes.Split(bytes.TrimSpace(logBuffer.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+
+	var last map[string]any
+	require.NoError(t, json.Unmarshal(lines[2], &last))
+	assert.Equal(t, "give_up", last["event"])
+	assert.Equal(t, "permanent error", last["error"])
+	assert.NotContains(t, last, "fields")
+}
@@ -0,0 +1,47 @@
+package retryables
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RetryWithFields is like Retry, but appends fields as key=value pairs, sorted by key, to every line
+// the call writes to the logger. It lets callers attach request-scoped values such as request IDs
+// without sharing a logger between calls. The fields map is only read, never modified.
+func (r *Retryer) RetryWithFields(ctx context.Context, fields map[string]any, retryFunc RetryableFunc) error {
+	return r.run(ctx, &call{
+		fn: func(context.Context) error {
+			return retryFunc()
+		},
+		fields: fields,
+	})
+}
+
+// formatFields renders fields as " k1=v1 k2=v2". Values containing spaces, quotes or '=' are quoted.
+func formatFields(fields map[string]any) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		value := fmt.Sprint(fields[key])
+		if value == "" || strings.ContainsAny(value, " \"=") {
+			value = strconv.Quote(value)
+		}
+		b.WriteString(" ")
+		b.WriteString(key)
+		b.WriteString("=")
+		b.WriteString(value)
+	}
+	return b.String()
+}
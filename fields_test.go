@@ -0,0 +1,76 @@
+package retryables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_RetryWithFields(t *testing.T) {
+	var logBuffer bytes.Buffer
+
+	retryer := retryables.NewRetryer(&logBuffer)
+	retryer.SetCount(3)
+	retryer.SetClock(newFakeClock())
+
+	fields := map[string]any{"tenant": 42, "request_id": "abc-1", "op": "get user"}
+	err := retryer.RetryWithFields(context.Background(), fields, func() error {
+		return errors.New("some error")
+	})
+	assert.Error(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(logBuffer.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		`Attempt 1/3 failed: some error op="get user" request_id=abc-1 tenant=42`,
+		`Attempt 2/3 failed: some error op="get user" request_id=abc-1 tenant=42`,
+		`Attempt 3/3 failed: some error op="get user" request_id=abc-1 tenant=42`,
+	}, lines)
+}
+
+func TestRetryer_RetryWithFields_Concurrent(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		logBuffer bytes.Buffer
+	)
+
+	retryer := retryables.NewRetryer(writerFunc(func(p []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return logBuffer.Write(p)
+	}))
+	retryer.SetCount(2)
+	retryer.SetClock(newFakeClock())
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = retryer.RetryWithFields(context.Background(), map[string]any{"id": id}, func() error {
+				return errors.New("some error")
+			})
+		}()
+	}
+	wg.Wait()
+
+	// plain Retry calls don't inherit fields of other calls
+	_ = retryer.Retry(context.Background(), func() error { return errors.New("plain error") })
+
+	logOutput := logBuffer.String()
+	assert.Equal(t, 1, strings.Count(logOutput, "Attempt 1/2 failed: some error id=a\n"))
+	assert.Equal(t, 1, strings.Count(logOutput, "Attempt 1/2 failed: some error id=b\n"))
+	assert.Contains(t, logOutput, "Attempt 1/2 failed: plain error\n")
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,76 @@
+package retryables
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetryMeta executes fn with retries, like Retry, but lets decide control the retries based on the
+// metadata fn reports with each attempt (e.g. the number of bytes transferred before a failure).
+// After every failed attempt, decide receives the metadata and error of the attempt and returns whether
+// to retry and how long to sleep before the next attempt; the sleep replaces the configured backoff.
+// decide alone controls the retries: the condition func, grace attempts and always-retry errors of r are
+// not used; only errors marked permanent (see Permanent and SetPermanentIf) stop the retries regardless.
+// The attempt count and the context still bound the retries.
+// It returns the metadata and error of the last attempt.
+func RetryMeta[M any](ctx context.Context, r *Retryer, fn func() (M, error),
+	decide func(M, error) (retry bool, delay time.Duration)) (M, error) {
+	var (
+		meta  M
+		retry bool
+		delay time.Duration
+	)
+
+	err := r.run(ctx, &call{
+		fn: func(context.Context) error {
+			var err error
+			meta, err = fn()
+			if err != nil {
+				retry, delay = decide(meta, err)
+			}
+			return err
+		},
+		shouldRetry: func(error) bool {
+			return retry
+		},
+		delay: func(int) (time.Duration, bool) {
+			return delay, true
+		},
+		hasResult: true,
+	})
+	return meta, err
+}
+
+// RetryWithData executes fn with retries, like Retry, and returns the value of the successful attempt.
+// If all attempts fail, it returns the zero value of T and the error.
+func RetryWithData[T any](ctx context.Context, r *Retryer, fn func() (T, error)) (T, error) {
+	var data T
+	err := r.run(ctx, &call{
+		fn: func(context.Context) error {
+			var err error
+			data, err = fn()
+			return err
+		},
+		hasResult: true,
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return data, nil
+}
+
+// RetryOrDefault is like RetryWithData, but returns def instead of an error if all attempts fail.
+// It suits best-effort reads that have a sensible fallback, such as feature flag defaults.
+// The swallowed error is written to the logger of r.
+func RetryOrDefault[T any](ctx context.Context, r *Retryer, fn func() (T, error), def T) T {
+	data, err := RetryWithData(ctx, r, fn)
+	if err != nil {
+		if !r.jsonEvents {
+			_, _ = fmt.Fprintf(r.logger, "Retry failed, using default value: %v\n", err)
+		}
+		return def
+	}
+	return data
+}
@@ -0,0 +1,134 @@
+package retryables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryMeta(t *testing.T) {
+	errInterrupted := errors.New("transfer interrupted")
+
+	tests := []struct {
+		name        string
+		transferred []int
+		expectErr   bool
+		expectTries int
+	}{
+		{
+			name:        "Retries while making progress",
+			transferred: []int{100, 50, 10, 1000},
+			expectErr:   false,
+			expectTries: 4,
+		},
+		{
+			name:        "Stops without progress",
+			transferred: []int{100, 0, 1000},
+			expectErr:   true,
+			expectTries: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := newFakeClock()
+			retryer := retryables.NewRetryer(nil)
+			retryer.SetCount(5)
+			retryer.SetClock(clock)
+			retryer.SetRecordSchedule(true)
+
+			tries := 0
+			total, err := retryables.RetryMeta(context.Background(), retryer, func() (int, error) {
+				n := test.transferred[tries]
+				tries++
+				if tries < len(test.transferred) {
+					return n, errInterrupted
+				}
+				return n, nil
+			}, func(n int, err error) (bool, time.Duration) {
+				// back off less after a bigger partial transfer
+				return n > 0, time.Second / time.Duration(n+1)
+			})
+
+			assert.Equal(t, test.expectErr, err != nil)
+			assert.Equal(t, test.expectTries, tries)
+			assert.Equal(t, test.transferred[tries-1], total)
+
+			for i, sleep := range retryer.LastSchedule() {
+				assert.Equal(t, time.Second/time.Duration(test.transferred[i]+1), sleep)
+			}
+		})
+	}
+}
+
+func TestRetryMeta_DecideIsAuthoritative(t *testing.T) {
+	errInterrupted := errors.New("transfer interrupted")
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(5)
+	retryer.SetClock(newFakeClock())
+	retryer.SetGraceAttempts(1)
+	retryer.SetAlwaysRetry(errInterrupted)
+
+	tries := 0
+	_, err := retryables.RetryMeta(context.Background(), retryer, func() (int, error) {
+		tries++
+		return 0, errInterrupted
+	}, func(int, error) (bool, time.Duration) {
+		return false, 0
+	})
+
+	assert.ErrorIs(t, err, errInterrupted)
+	assert.Equal(t, 1, tries)
+}
+
+func TestRetryWithData(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetClock(newFakeClock())
+
+	attempts := 0
+	data, err := retryables.RetryWithData(context.Background(), retryer, func() (string, error) {
+		attempts++
+		if attempts < 2 {
+			return "partial", errors.New("temporary error")
+		}
+		return "value", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", data)
+
+	data, err = retryables.RetryWithData(context.Background(), retryer, func() (string, error) {
+		return "partial", errors.New("permanent error")
+	})
+	assert.Error(t, err)
+	assert.Empty(t, data)
+}
+
+func TestRetryOrDefault(t *testing.T) {
+	var logBuffer bytes.Buffer
+
+	retryer := retryables.NewRetryer(&logBuffer)
+	retryer.SetCount(3)
+	retryer.SetClock(newFakeClock())
+
+	attempts := 0
+	enabled := retryables.RetryOrDefault(context.Background(), retryer, func() (bool, error) {
+		attempts++
+		return true, errors.New("flag service down")
+	}, false)
+	assert.False(t, enabled)
+	assert.Equal(t, 3, attempts)
+	assert.Contains(t, logBuffer.String(), "using default value: flag service down")
+
+	enabled = retryables.RetryOrDefault(context.Background(), retryer, func() (bool, error) {
+		return true, nil
+	}, false)
+	assert.True(t, enabled)
+}
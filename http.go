@@ -0,0 +1,108 @@
+package retryables
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxDrainBytes limits how much of a discarded response body is read so that the connection can be reused.
+const maxDrainBytes = 4 << 10
+
+// responseError reports a response that shouldRetry asked to retry although the request itself succeeded.
+type responseError struct {
+	resp *http.Response
+}
+
+func (e *responseError) Error() string {
+	return fmt.Sprintf("retryable response: %s", e.resp.Status)
+}
+
+// RetryHTTP executes do with retries, deciding whether to retry from both the response and the error
+// via shouldRetry instead of the configured condition func. shouldRetry alone decides: grace attempts,
+// always-retry errors and the abort signal do not override it, only errors marked permanent do.
+//
+// Responses that are discarded in favour of another attempt have their bodies drained and closed, so
+// the underlying connection can be reused. Rewinding the request body (if any) between attempts is the
+// caller's job, typically by building a fresh request inside do.
+//
+// If a retryable response carries a Retry-After header, it replaces the backoff before the next attempt.
+//
+// When attempts run out on a retryable response, that last response is returned with a nil error and its
+// body left open for the caller, mirroring http.Client, which does not treat status codes as errors.
+// If Retry fails with an error (transport error, cancelled context), any pending response is closed and nil is returned.
+func (r *Retryer) RetryHTTP(ctx context.Context, do func(ctx context.Context) (*http.Response, error),
+	shouldRetry func(*http.Response, error) bool) (*http.Response, error) {
+	var (
+		resp  *http.Response
+		retry bool
+	)
+
+	err := r.run(ctx, &call{
+		fn: func(ctx context.Context) error {
+			if resp != nil {
+				drainAndClose(resp)
+				resp = nil
+			}
+
+			var err error
+			resp, err = do(ctx)
+			retry = shouldRetry(resp, err)
+			if err == nil && retry {
+				return &responseError{resp: resp}
+			}
+			return err
+		},
+		shouldRetry: func(error) bool {
+			return retry
+		},
+		delay: func(int) (time.Duration, bool) {
+			if resp == nil {
+				return 0, false
+			}
+			return parseRetryAfter(resp.Header.Get("Retry-After"), r.clock.Now())
+		},
+		hasResult: true,
+	})
+
+	var respErr *responseError
+	if errors.As(err, &respErr) {
+		return resp, nil
+	}
+	if err != nil {
+		if resp != nil {
+			drainAndClose(resp)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func drainAndClose(resp *http.Response) {
+	if resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+	_ = resp.Body.Close()
+}
+
+// parseRetryAfter parses a Retry-After header value, given either as delay-seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
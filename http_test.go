@@ -0,0 +1,158 @@
+package retryables_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func retryOn5xx(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= 500
+}
+
+func TestRetryer_RetryHTTP(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		expectStatus int
+		expectTries  int
+	}{
+		{
+			name:         "Success after retryable statuses",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectStatus: http.StatusOK,
+			expectTries:  3,
+		},
+		{
+			name:         "Non-retryable status",
+			statuses:     []int{http.StatusBadRequest, http.StatusOK},
+			expectStatus: http.StatusBadRequest,
+			expectTries:  1,
+		},
+		{
+			name:         "Exhausted returns last response",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expectStatus: http.StatusServiceUnavailable,
+			expectTries:  3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tries := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := test.statuses[tries]
+				tries++
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+				_, _ = io.WriteString(w, http.StatusText(status))
+			}))
+			defer server.Close()
+
+			retryer := retryables.NewRetryer(nil)
+			retryer.SetCount(3)
+
+			resp, err := retryer.RetryHTTP(context.Background(), func(ctx context.Context) (*http.Response, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+				if err != nil {
+					return nil, err
+				}
+				return http.DefaultClient.Do(req)
+			}, retryOn5xx)
+
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, test.expectStatus, resp.StatusCode)
+			assert.Equal(t, test.expectTries, tries)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusText(test.expectStatus), string(body))
+		})
+	}
+}
+
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestRetryer_RetryHTTP_ClosesDiscardedBodies(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetDelay(time.Millisecond, time.Millisecond)
+
+	var bodies []*trackingBody
+	resp, err := retryer.RetryHTTP(context.Background(), func(ctx context.Context) (*http.Response, error) {
+		body := &trackingBody{Reader: strings.NewReader("body")}
+		bodies = append(bodies, body)
+		status := http.StatusServiceUnavailable
+		if len(bodies) == 3 {
+			status = http.StatusOK
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: body}, nil
+	}, retryOn5xx)
+
+	require.NoError(t, err)
+	require.Len(t, bodies, 3)
+	assert.True(t, bodies[0].closed)
+	assert.True(t, bodies[1].closed)
+	assert.False(t, bodies[2].closed, "returned response must be left open")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRetryer_RetryHTTP_RetryAfter(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(2)
+	retryer.SetDelay(time.Millisecond, time.Millisecond)
+
+	tries := 0
+	start := time.Now()
+	resp, err := retryer.RetryHTTP(context.Background(), func(ctx context.Context) (*http.Response, error) {
+		tries++
+		if tries == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"1"}},
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+	}, func(resp *http.Response, err error) bool {
+		return err != nil || resp.StatusCode == http.StatusTooManyRequests
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRetryer_RetryHTTP_ContextCancelled(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	body := &trackingBody{Reader: strings.NewReader("")}
+	resp, err := retryer.RetryHTTP(ctx, func(ctx context.Context) (*http.Response, error) {
+		cancel()
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: body}, nil
+	}, retryOn5xx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, resp)
+	assert.True(t, body.closed)
+}
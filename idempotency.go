@@ -0,0 +1,47 @@
+package retryables
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrIdempotencyKeyRequired is returned by Validate and Retry when SetRequireIdempotent(true) is in effect
+// but no idempotency key func has been set.
+var ErrIdempotencyKeyRequired = errors.New("retryables: idempotent retries required but no idempotency key func is set")
+
+type idempotencyKeyCtxKey struct{}
+
+// IdempotencyKey returns the idempotency key carried by an attempt context passed by RetryCtx.
+// The key is generated once per Retry call, so every attempt of the call sees the same key.
+func IdempotencyKey(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	return key, ok
+}
+
+// SetIdempotencyKeyFunc sets the generator of idempotency keys. It is called once at the start of every
+// Retry call, and the key is made available to all attempts of that call through IdempotencyKey.
+// Passing the key along with a non-idempotent request (e.g. as an Idempotency-Key header) lets the server
+// deduplicate a retry that follows a partially successful attempt.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetIdempotencyKeyFunc(keyFunc func() string) {
+	r.idempotencyKeyFunc = keyFunc
+}
+
+// SetRequireIdempotent enables strict mode for non-idempotent operations: while it is on, Retry refuses
+// to run without an idempotency key func and returns ErrIdempotencyKeyRequired before the first attempt.
+// Call Validate once the retryer is configured to catch the missing key func at startup.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetRequireIdempotent(require bool) {
+	r.requireIdempotent = require
+}
+
+// Validate reports a configuration Retry would refuse to run with, so it can be caught when the retryer
+// is set up rather than on its first call. It returns ErrIdempotencyKeyRequired if strict mode is on
+// (see SetRequireIdempotent) without an idempotency key func. Retry makes the same check on every call
+// and records such a refused call in stats and metrics like any other, with zero attempts.
+func (r *Retryer) Validate() error {
+	if r.requireIdempotent && r.idempotencyKeyFunc == nil {
+		return ErrIdempotencyKeyRequired
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_RequireIdempotent(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetRequireIdempotent(true)
+
+	tries := 0
+	err := retryer.Retry(context.Background(), func() error {
+		tries++
+		return nil
+	})
+
+	assert.ErrorIs(t, err, retryables.ErrIdempotencyKeyRequired)
+	assert.Equal(t, 0, tries)
+}
+
+func TestRetryer_RequireIdempotentBookkeeping(t *testing.T) {
+	var counters retryables.ExitCounters
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetRequireIdempotent(true)
+	retryer.SetStatsEnabled(true)
+	retryer.SetMetrics(&counters)
+
+	result, err := retryer.RetryWithResult(context.Background(), func() error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, retryables.ErrIdempotencyKeyRequired)
+	assert.Equal(t, retryables.ExitNonRetryable, result.ExitReason)
+	assert.Equal(t, 0, result.Attempts)
+	assert.Equal(t, int64(1), counters.Count(retryables.ExitNonRetryable))
+	stats := retryer.Stats()
+	assert.Equal(t, int64(1), stats.Calls)
+	assert.Equal(t, int64(1), stats.GiveUps)
+}
+
+func TestRetryer_Validate(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	assert.NoError(t, retryer.Validate())
+
+	retryer.SetRequireIdempotent(true)
+	assert.ErrorIs(t, retryer.Validate(), retryables.ErrIdempotencyKeyRequired)
+
+	retryer.SetIdempotencyKeyFunc(func() string { return "key" })
+	assert.NoError(t, retryer.Validate())
+}
+
+func TestRetryer_IdempotencyKey(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetClock(newFakeClock())
+	retryer.SetRequireIdempotent(true)
+
+	generated := 0
+	retryer.SetIdempotencyKeyFunc(func() string {
+		generated++
+		return fmt.Sprintf("key-%d", generated)
+	})
+
+	for _, expectKey := range []string{"key-1", "key-2"} {
+		var keys []string
+		err := retryer.RetryCtx(context.Background(), func(ctx context.Context) error {
+			key, ok := retryables.IdempotencyKey(ctx)
+			assert.True(t, ok)
+			keys = append(keys, key)
+			if len(keys) < 3 {
+				return errors.New("temporary error")
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{expectKey, expectKey, expectKey}, keys)
+	}
+}
+
+func TestIdempotencyKey_Missing(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+
+	err := retryer.RetryCtx(context.Background(), func(ctx context.Context) error {
+		_, ok := retryables.IdempotencyKey(ctx)
+		assert.False(t, ok)
+		return nil
+	})
+	assert.NoError(t, err)
+}
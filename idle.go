@@ -0,0 +1,54 @@
+package retryables
+
+import (
+	"sync"
+	"time"
+)
+
+// idleState carries the backoff level of a retryer across Retry calls while shared backoff is enabled.
+type idleState struct {
+	mu          sync.Mutex
+	failures    int
+	lastAttempt time.Time
+}
+
+// resume returns the backoff level a call starting at now continues from.
+func (s *idleState) resume(now time.Time, idleReset time.Duration) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if idleReset > 0 && now.Sub(s.lastAttempt) > idleReset {
+		s.failures = 0
+	}
+	return s.failures
+}
+
+// save stores the backoff level reached by a call that finished at now with err.
+func (s *idleState) save(now time.Time, failures int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		failures = 0
+	}
+	s.failures = failures
+	s.lastAttempt = now
+}
+
+// SetSharedBackoff makes the backoff level shared across the Retry calls of the retryer: a call that
+// starts after a failed one continues its backoff instead of starting over from baseDelay, so a burst of
+// failing calls keeps backing off. A successful call restarts it, and so does idleness with SetIdleReset.
+// Concurrent calls each continue from the level saved when they start and save their own level when they
+// return, so the last call to return wins. It is off by default: every call has its own fresh backoff.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetSharedBackoff(enabled bool) {
+	r.sharedBackoff = enabled
+}
+
+// SetIdleReset restarts the shared backoff (see SetSharedBackoff) from baseDelay once more than idleReset
+// has passed since the last attempt of any call. Zero, the default, keeps it until a call succeeds.
+// It has no effect unless the backoff is shared.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetIdleReset(idleReset time.Duration) {
+	r.idleReset = idleReset
+}
@@ -0,0 +1,122 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_IdleReset(t *testing.T) {
+	const seed = 3
+
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetDelay(time.Second, time.Hour)
+	retryer.SetClock(clock)
+	retryer.SetRand(rand.New(rand.NewSource(seed)))
+	retryer.SetRecordSchedule(true)
+	retryer.SetSharedBackoff(true)
+	retryer.SetIdleReset(time.Minute)
+
+	ref := rand.New(rand.NewSource(seed))
+	expectSchedule := func(attempts ...int) []time.Duration {
+		schedule := make([]time.Duration, 0, len(attempts))
+		for _, attempt := range attempts {
+			schedule = append(schedule, time.Duration(ref.Int63n(int64(retryer.ComputeBackoff(attempt)))))
+		}
+		return schedule
+	}
+	fail := func() error { return errors.New("permanent error") }
+
+	_ = retryer.Retry(context.Background(), fail)
+	assert.Equal(t, expectSchedule(0, 1), retryer.LastSchedule())
+
+	// within the idle period, the backoff continues
+	clock.Advance(30 * time.Second)
+	_ = retryer.Retry(context.Background(), fail)
+	assert.Equal(t, expectSchedule(2, 3), retryer.LastSchedule())
+
+	// after the idle period, the backoff restarts
+	clock.Advance(2 * time.Minute)
+	_ = retryer.Retry(context.Background(), fail)
+	assert.Equal(t, expectSchedule(0, 1), retryer.LastSchedule())
+}
+
+func TestRetryer_IdleReset_Success(t *testing.T) {
+	const seed = 5
+
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetDelay(time.Second, time.Hour)
+	retryer.SetClock(clock)
+	retryer.SetRand(rand.New(rand.NewSource(seed)))
+	retryer.SetRecordSchedule(true)
+	retryer.SetSharedBackoff(true)
+	retryer.SetIdleReset(time.Minute)
+
+	_ = retryer.Retry(context.Background(), func() error { return errors.New("permanent error") })
+	_ = retryer.Retry(context.Background(), func() error { return nil })
+
+	attempts := 0
+	_ = retryer.Retry(context.Background(), func() error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("temporary error")
+		}
+		return nil
+	})
+
+	ref := rand.New(rand.NewSource(seed))
+	_, _ = ref.Int63n(int64(retryer.ComputeBackoff(0))), ref.Int63n(int64(retryer.ComputeBackoff(1)))
+	expect := time.Duration(ref.Int63n(int64(retryer.ComputeBackoff(0))))
+	assert.Equal(t, []time.Duration{expect}, retryer.LastSchedule())
+}
+
+func TestRetryer_SharedBackoffDisabled(t *testing.T) {
+	const seed = 7
+
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetDelay(time.Second, time.Hour)
+	retryer.SetClock(clock)
+	retryer.SetRand(rand.New(rand.NewSource(seed)))
+	retryer.SetRecordSchedule(true)
+	retryer.SetIdleReset(time.Minute) // no effect without shared backoff
+
+	ref := rand.New(rand.NewSource(seed))
+	fail := func() error { return errors.New("permanent error") }
+	for i := 0; i < 2; i++ {
+		_ = retryer.Retry(context.Background(), fail)
+		expect := []time.Duration{
+			time.Duration(ref.Int63n(int64(retryer.ComputeBackoff(0)))),
+			time.Duration(ref.Int63n(int64(retryer.ComputeBackoff(1)))),
+		}
+		assert.Equal(t, expect, retryer.LastSchedule(), "call %d starts over", i+1)
+	}
+}
+
+func TestRetryer_SharedBackoffWithoutIdleReset(t *testing.T) {
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(2)
+	retryer.SetDelay(time.Second, time.Hour)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(clock)
+	retryer.SetRecordSchedule(true)
+	retryer.SetSharedBackoff(true)
+
+	fail := func() error { return errors.New("permanent error") }
+	_ = retryer.Retry(context.Background(), fail)
+	clock.Advance(24 * time.Hour)
+	_ = retryer.Retry(context.Background(), fail)
+	assert.Equal(t, []time.Duration{2 * time.Second}, retryer.LastSchedule())
+}
@@ -0,0 +1,52 @@
+package retryables
+
+import "fmt"
+
+// Jitter is a way of randomizing the sleep between attempts.
+type Jitter int
+
+const (
+	// JitterFull sleeps a random duration in [0, backoff).
+	JitterFull Jitter = iota
+	// JitterNone sleeps exactly the backoff.
+	JitterNone
+	// JitterBounded sleeps backoff * (1 + u), u drawn uniformly from [-variance, +variance], capped at
+	// maxDelay. It keeps sleeps close to the backoff for predictable tail latency, see SetBoundedJitter.
+	JitterBounded
+)
+
+// defaultJitterVariance is the variance of JitterBounded unless set via SetBoundedJitter.
+const defaultJitterVariance = 0.1
+
+func (j Jitter) String() string {
+	switch j {
+	case JitterFull:
+		return "full"
+	case JitterNone:
+		return "none"
+	case JitterBounded:
+		return "bounded"
+	default:
+		return fmt.Sprintf("Jitter(%d)", int(j))
+	}
+}
+
+// ParseJitter returns the Jitter named s, as returned by Jitter.String.
+func ParseJitter(s string) (Jitter, error) {
+	for _, j := range []Jitter{JitterFull, JitterNone, JitterBounded} {
+		if j.String() == s {
+			return j, nil
+		}
+	}
+	return 0, fmt.Errorf("retryables: unknown jitter %q", s)
+}
+
+// SetBoundedJitter switches to JitterBounded with the given variance, e.g. 0.1 for sleeps within ±10%
+// of the backoff. Full jitter gives a low average but a wide spread; bounded jitter still decorrelates
+// concurrent callers while keeping every sleep predictable. The variance is clamped to [0, 1];
+// SetJitter(JitterBounded) alone uses a variance of 0.1.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetBoundedJitter(variance float64) {
+	r.jitterMode = JitterBounded
+	r.jitterVariance = min(max(variance, 0), 1)
+}
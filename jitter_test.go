@@ -0,0 +1,68 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_BoundedJitter(t *testing.T) {
+	const variance = 0.1
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(4)
+	retryer.SetDelay(100*time.Millisecond, 10*time.Second)
+	retryer.SetClock(newFakeClock())
+	retryer.SetRand(rand.New(rand.NewSource(1)))
+	retryer.SetRecordSchedule(true)
+	retryer.SetBoundedJitter(variance)
+
+	for run := 0; run < 200; run++ {
+		_ = retryer.Retry(context.Background(), func() error {
+			return errors.New("permanent error")
+		})
+
+		for attempt, sleep := range retryer.LastSchedule() {
+			backoff := float64(retryer.ComputeBackoff(attempt))
+			assert.GreaterOrEqual(t, float64(sleep), backoff*(1-variance))
+			assert.LessOrEqual(t, float64(sleep), backoff*(1+variance))
+		}
+	}
+}
+
+func TestRetryer_BoundedJitter_MaxDelay(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetDelay(time.Second, time.Second)
+	retryer.SetClock(newFakeClock())
+	retryer.SetRecordSchedule(true)
+	retryer.SetBoundedJitter(0.5)
+
+	for run := 0; run < 50; run++ {
+		_ = retryer.Retry(context.Background(), func() error {
+			return errors.New("permanent error")
+		})
+
+		for _, sleep := range retryer.LastSchedule() {
+			assert.GreaterOrEqual(t, sleep, time.Second/2)
+			assert.LessOrEqual(t, sleep, time.Second)
+		}
+	}
+}
+
+func TestParseJitter(t *testing.T) {
+	for _, jitter := range []retryables.Jitter{retryables.JitterFull, retryables.JitterNone, retryables.JitterBounded} {
+		parsed, err := retryables.ParseJitter(jitter.String())
+		assert.NoError(t, err)
+		assert.Equal(t, jitter, parsed)
+	}
+
+	_, err := retryables.ParseJitter("half")
+	assert.Error(t, err)
+}
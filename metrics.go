@@ -0,0 +1,71 @@
+package retryables
+
+import "sync/atomic"
+
+// ExitReason tells why a Retry call stopped.
+type ExitReason int
+
+const (
+	ExitUnknown          ExitReason = iota // the zero value, no reason was recorded
+	ExitSuccess                            // an attempt succeeded
+	ExitExhausted                          // every attempt failed
+	ExitNonRetryable                       // an attempt or precheck failed with an error that is not retried
+	ExitContextCancelled                   // the context was done before the next attempt
+	ExitBudgetExhausted                    // the next sleep would end past the stop time (SetMaxElapsed, RetryStopAt)
+
+	exitReasons = iota
+)
+
+var exitReasonNames = [exitReasons]string{
+	ExitUnknown:          "unknown",
+	ExitSuccess:          "success",
+	ExitExhausted:        "exhausted",
+	ExitNonRetryable:     "non_retryable",
+	ExitContextCancelled: "context_cancelled",
+	ExitBudgetExhausted:  "budget_exhausted",
+}
+
+// String returns the name of the reason, suitable as a metric label, e.g. "non_retryable".
+func (e ExitReason) String() string {
+	if e <= ExitUnknown || e >= exitReasons {
+		return "unknown"
+	}
+	return exitReasonNames[e]
+}
+
+// Metrics receives the outcome of every Retry call.
+// Its methods are called from Retry and must be safe for concurrent use.
+type Metrics interface {
+	// IncExit counts a call that stopped for the given reason.
+	IncExit(reason ExitReason)
+}
+
+// SetMetrics sets where the exit reason of every Retry call is reported, e.g. an ExitCounters
+// or an adapter to a metrics library. Passing nil disables reporting.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetMetrics(metrics Metrics) {
+	r.metrics = metrics
+}
+
+// ExitCounters is a Metrics that keeps a counter per exit reason.
+// The zero value is ready to use and it is safe for concurrent use.
+type ExitCounters struct {
+	counts [exitReasons]atomic.Int64
+}
+
+var _ Metrics = (*ExitCounters)(nil)
+
+// IncExit increments the counter of reason.
+func (c *ExitCounters) IncExit(reason ExitReason) {
+	if reason >= 0 && reason < exitReasons {
+		c.counts[reason].Add(1)
+	}
+}
+
+// Count returns the number of calls that stopped for reason.
+func (c *ExitCounters) Count(reason ExitReason) int64 {
+	if reason < 0 || reason >= exitReasons {
+		return 0
+	}
+	return c.counts[reason].Load()
+}
@@ -0,0 +1,161 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_Metrics(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		run    func(r *retryables.Retryer, clock *fakeClock) error
+		expect retryables.ExitReason
+	}{
+		{
+			name: "success",
+			run: func(r *retryables.Retryer, _ *fakeClock) error {
+				calls := 0
+				return r.Retry(context.Background(), func() error {
+					calls++
+					if calls < 2 {
+						return errTemporary
+					}
+					return nil
+				})
+			},
+			expect: retryables.ExitSuccess,
+		},
+		{
+			name: "exhausted",
+			run: func(r *retryables.Retryer, _ *fakeClock) error {
+				return r.Retry(context.Background(), func() error {
+					return errTemporary
+				})
+			},
+			expect: retryables.ExitExhausted,
+		},
+		{
+			name: "non retryable",
+			run: func(r *retryables.Retryer, _ *fakeClock) error {
+				return r.Retry(context.Background(), func() error {
+					return retryables.Permanent(errTemporary)
+				})
+			},
+			expect: retryables.ExitNonRetryable,
+		},
+		{
+			name: "context cancelled",
+			run: func(r *retryables.Retryer, _ *fakeClock) error {
+				return r.Retry(cancelled, func() error {
+					return errTemporary
+				})
+			},
+			expect: retryables.ExitContextCancelled,
+		},
+		{
+			name: "budget exhausted",
+			run: func(r *retryables.Retryer, clock *fakeClock) error {
+				stop := clock.Now().Add(150 * time.Millisecond)
+				return r.RetryStopAt(context.Background(), stop, func() error {
+					return errTemporary
+				})
+			},
+			expect: retryables.ExitBudgetExhausted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var counters retryables.ExitCounters
+			clock := newFakeClock()
+			retryer := retryables.NewRetryer(nil)
+			retryer.SetCount(3)
+			retryer.SetDelay(100*time.Millisecond, time.Second)
+			retryer.SetJitter(retryables.JitterNone)
+			retryer.SetClock(clock)
+			retryer.SetMetrics(&counters)
+
+			_ = test.run(retryer, clock)
+
+			for reason := retryables.ExitSuccess; reason <= retryables.ExitBudgetExhausted; reason++ {
+				expect := int64(0)
+				if reason == test.expect {
+					expect = 1
+				}
+				assert.Equal(t, expect, counters.Count(reason), reason.String())
+			}
+		})
+	}
+}
+
+func TestRetryer_MetricsPrecheck(t *testing.T) {
+	var counters retryables.ExitCounters
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetConditionFunc(func(err error) bool {
+		return err.Error() != "down for maintenance"
+	})
+	retryer.SetCount(2)
+	retryer.SetClock(newFakeClock())
+	retryer.SetMetrics(&counters)
+
+	precheckErr := errors.New("circuit open")
+	retryer.SetPrecheck(func(context.Context) error {
+		return precheckErr
+	})
+	_ = retryer.Retry(context.Background(), func() error { return nil })
+	assert.Equal(t, int64(1), counters.Count(retryables.ExitExhausted))
+
+	precheckErr = errors.New("down for maintenance")
+	_ = retryer.Retry(context.Background(), func() error { return nil })
+	assert.Equal(t, int64(1), counters.Count(retryables.ExitNonRetryable))
+	assert.Equal(t, int64(0), counters.Count(retryables.ExitSuccess))
+}
+
+func TestRetryWithResult_ExitReason(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(2)
+	retryer.SetClock(newFakeClock())
+
+	result, err := retryer.RetryWithResult(context.Background(), func() error {
+		return errors.New("temporary")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, retryables.ExitExhausted, result.ExitReason)
+
+	result, err = retryer.RetryWithResult(context.Background(), func() error {
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, retryables.ExitSuccess, result.ExitReason)
+}
+
+func TestRetryWithResult_ExitReasonBeforeFirstAttempt(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetRequireIdempotent(true)
+
+	result, err := retryer.RetryWithResult(context.Background(), func() error {
+		return nil
+	})
+	assert.ErrorIs(t, err, retryables.ErrIdempotencyKeyRequired)
+	assert.Equal(t, retryables.ExitNonRetryable, result.ExitReason)
+}
+
+func TestExitReason_String(t *testing.T) {
+	assert.Equal(t, "success", retryables.ExitSuccess.String())
+	assert.Equal(t, "exhausted", retryables.ExitExhausted.String())
+	assert.Equal(t, "non_retryable", retryables.ExitNonRetryable.String())
+	assert.Equal(t, "context_cancelled", retryables.ExitContextCancelled.String())
+	assert.Equal(t, "budget_exhausted", retryables.ExitBudgetExhausted.String())
+	assert.Equal(t, "unknown", retryables.ExitUnknown.String())
+	assert.Equal(t, "unknown", retryables.ExitReason(-1).String())
+}
@@ -0,0 +1,67 @@
+package retryables
+
+import "errors"
+
+// permanentError marks an error that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent wraps err so that Retry stops immediately on it, whatever the condition func says,
+// and returns err itself. It returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func unwrapPermanent(err error) error {
+	var permanentErr *permanentError
+	if errors.As(err, &permanentErr) {
+		return permanentErr.err
+	}
+	return err
+}
+
+// SetPermanentIf sets a predicate that marks errors as permanent, as if they were wrapped with Permanent
+// at the return site. It is checked before the condition func and grace attempts, so a permanent error
+// always stops Retry on its first occurrence.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetPermanentIf(permanentIf func(error) bool) {
+	r.permanentIf = permanentIf
+}
+
+func (r *Retryer) isPermanent(err error) bool {
+	var permanentErr *permanentError
+	if errors.As(err, &permanentErr) {
+		return true
+	}
+	return r.permanentIf != nil && r.permanentIf(err)
+}
+
+// SetAlwaysRetry lists errors that are always transient: an attempt failing with an error matching one of
+// them (via errors.Is) is retried whatever the condition func says. Permanent errors, marked with Permanent
+// or SetPermanentIf, still take precedence and stop Retry. It does not apply to RetryMeta and RetryHTTP,
+// whose callers decide every retry themselves. Calling it again replaces the list.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetAlwaysRetry(errs ...error) {
+	r.alwaysRetry = append([]error(nil), errs...)
+}
+
+func (r *Retryer) isAlwaysRetry(err error) bool {
+	for _, alwaysRetry := range r.alwaysRetry {
+		if errors.Is(err, alwaysRetry) {
+			return true
+		}
+	}
+	return false
+}
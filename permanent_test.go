@@ -0,0 +1,115 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+type validationError struct {
+	field string
+}
+
+func (e *validationError) Error() string {
+	return fmt.Sprintf("invalid %s", e.field)
+}
+
+func TestRetryer_Permanent(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetGraceAttempts(1)
+
+	errNotFound := errors.New("not found")
+	attempts := 0
+	err := retryer.Retry(context.Background(), func() error {
+		attempts++
+		return retryables.Permanent(errNotFound)
+	})
+
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, errNotFound, err)
+	assert.NoError(t, retryables.Permanent(nil))
+}
+
+func TestRetryer_SetPermanentIf(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(5)
+	retryer.SetClock(newFakeClock())
+	retryer.SetConditionFunc(func(err error) bool {
+		return err != nil // retry everything...
+	})
+	retryer.SetPermanentIf(func(err error) bool {
+		var validationErr *validationError
+		return errors.As(err, &validationErr) // ...but validation errors
+	})
+
+	attempts := 0
+	err := retryer.Retry(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("temporary error")
+		}
+		return fmt.Errorf("create user: %w", &validationError{field: "email"})
+	})
+
+	assert.Equal(t, 3, attempts)
+	var validationErr *validationError
+	assert.ErrorAs(t, err, &validationErr)
+
+	err, retryable := retryer.TryOnce(context.Background(), func() error {
+		return &validationError{field: "name"}
+	})
+	assert.Error(t, err)
+	assert.False(t, retryable)
+}
+
+func TestRetryer_SetAlwaysRetry(t *testing.T) {
+	errThrottled := errors.New("throttled")
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(5)
+	retryer.SetClock(newFakeClock())
+	retryer.SetConditionFunc(func(err error) bool {
+		return false // retry nothing...
+	})
+	retryer.SetAlwaysRetry(errThrottled) // ...but throttling
+
+	attempts := 0
+	err := retryer.Retry(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("call: %w", errThrottled)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	_, retryable := retryer.TryOnce(context.Background(), func() error { return errThrottled })
+	assert.True(t, retryable)
+}
+
+func TestRetryer_SetAlwaysRetry_PermanentWins(t *testing.T) {
+	errThrottled := errors.New("throttled")
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(5)
+	retryer.SetClock(newFakeClock())
+	retryer.SetAlwaysRetry(errThrottled)
+	retryer.SetPermanentIf(func(err error) bool {
+		return errors.Is(err, errThrottled)
+	})
+
+	attempts := 0
+	err := retryer.Retry(context.Background(), func() error {
+		attempts++
+		return errThrottled
+	})
+	assert.ErrorIs(t, err, errThrottled)
+	assert.Equal(t, 1, attempts)
+}
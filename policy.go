@@ -0,0 +1,104 @@
+package retryables
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrInvalidPolicy is returned by NewRetryerFromPolicy for a policy that fails validation.
+var ErrInvalidPolicy = errors.New("retryables: invalid policy")
+
+// Policy is a declarative retry configuration, meant to be filled from the configuration format
+// of the application (YAML, JSON, flags...) and turned into a Retryer by NewRetryerFromPolicy.
+type Policy struct {
+	MaxAttempts int           // number of attempts, at least 1
+	BaseDelay   time.Duration // backoff after the first failed attempt
+	MaxDelay    time.Duration // cap of the backoff, at least BaseDelay
+	Multiplier  float64       // backoff growth factor, at least 1; zero means the default of 2
+	Jitter      string        // "full", "none" or "bounded" (±10%); empty means "full"
+	// RetryableErrors are names of errors registered with RegisterError. If set, only errors matching
+	// one of them (via errors.Is) are retried; otherwise any error is.
+	RetryableErrors []string
+}
+
+var errorRegistry = struct {
+	sync.RWMutex
+	errs map[string]error
+}{errs: make(map[string]error)}
+
+// RegisterError makes err available to policies under name, see Policy.RetryableErrors.
+// Registering a name again replaces the previous error. It is safe for concurrent use.
+func RegisterError(name string, err error) {
+	errorRegistry.Lock()
+	defer errorRegistry.Unlock()
+	errorRegistry.errs[name] = err
+}
+
+func lookupError(name string) (error, bool) {
+	errorRegistry.RLock()
+	defer errorRegistry.RUnlock()
+	err, ok := errorRegistry.errs[name]
+	return err, ok
+}
+
+// NewRetryerFromPolicy validates p and returns a Retryer configured by it.
+// The returned error wraps ErrInvalidPolicy and lists every problem found.
+func NewRetryerFromPolicy(p Policy, logger io.Writer) (*Retryer, error) {
+	var problems []error
+	if p.MaxAttempts < 1 {
+		problems = append(problems, fmt.Errorf("max attempts %d is less than 1", p.MaxAttempts))
+	}
+	if p.BaseDelay < 0 {
+		problems = append(problems, fmt.Errorf("base delay %s is negative", p.BaseDelay))
+	}
+	if p.MaxDelay < p.BaseDelay {
+		problems = append(problems, fmt.Errorf("max delay %s is less than base delay %s", p.MaxDelay, p.BaseDelay))
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		problems = append(problems, fmt.Errorf("multiplier %v is less than 1", p.Multiplier))
+	}
+
+	jitter := JitterFull
+	if p.Jitter != "" {
+		var err error
+		if jitter, err = ParseJitter(p.Jitter); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	retryableErrs := make([]error, 0, len(p.RetryableErrors))
+	for _, name := range p.RetryableErrors {
+		err, ok := lookupError(name)
+		if !ok {
+			problems = append(problems, fmt.Errorf("retryable error %q is not registered", name))
+			continue
+		}
+		retryableErrs = append(retryableErrs, err)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, errors.Join(problems...))
+	}
+
+	r := NewRetryer(logger)
+	r.SetCount(p.MaxAttempts)
+	r.SetDelay(p.BaseDelay, p.MaxDelay)
+	if p.Multiplier != 0 {
+		r.SetMultiplier(p.Multiplier)
+	}
+	r.SetJitter(jitter)
+	if len(retryableErrs) > 0 {
+		r.SetConditionFunc(func(err error) bool {
+			for _, retryableErr := range retryableErrs {
+				if errors.Is(err, retryableErr) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return r, nil
+}
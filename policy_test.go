@@ -0,0 +1,88 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+var errPolicyUnavailable = errors.New("unavailable")
+
+func init() {
+	retryables.RegisterError("unavailable", errPolicyUnavailable)
+}
+
+func TestNewRetryerFromPolicy(t *testing.T) {
+	retryer, err := retryables.NewRetryerFromPolicy(retryables.Policy{
+		MaxAttempts:     4,
+		BaseDelay:       100 * time.Millisecond,
+		MaxDelay:        time.Second,
+		Multiplier:      3,
+		Jitter:          "none",
+		RetryableErrors: []string{"unavailable"},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 100*time.Millisecond, retryer.ComputeBackoff(0))
+	assert.Equal(t, 300*time.Millisecond, retryer.ComputeBackoff(1))
+	assert.Equal(t, 900*time.Millisecond, retryer.ComputeBackoff(2))
+	assert.Equal(t, time.Second, retryer.ComputeBackoff(3))
+
+	retryer.SetClock(newFakeClock())
+	retryer.SetRecordSchedule(true)
+
+	attempts := 0
+	err = retryer.Retry(context.Background(), func() error {
+		attempts++
+		return fmt.Errorf("call: %w", errPolicyUnavailable)
+	})
+	assert.ErrorIs(t, err, errPolicyUnavailable)
+	assert.Equal(t, 4, attempts)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond}, retryer.LastSchedule())
+
+	attempts = 0
+	err = retryer.Retry(context.Background(), func() error {
+		attempts++
+		return errors.New("not registered")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestNewRetryerFromPolicy_Invalid(t *testing.T) {
+	valid := retryables.Policy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 8 * time.Second}
+
+	tests := []struct {
+		name   string
+		modify func(p *retryables.Policy)
+	}{
+		{name: "No attempts", modify: func(p *retryables.Policy) { p.MaxAttempts = 0 }},
+		{name: "Negative base delay", modify: func(p *retryables.Policy) { p.BaseDelay = -time.Second }},
+		{name: "Max delay below base delay", modify: func(p *retryables.Policy) { p.MaxDelay = time.Millisecond }},
+		{name: "Shrinking multiplier", modify: func(p *retryables.Policy) { p.Multiplier = 0.5 }},
+		{name: "Unknown jitter", modify: func(p *retryables.Policy) { p.Jitter = "half" }},
+		{name: "Unregistered error", modify: func(p *retryables.Policy) { p.RetryableErrors = []string{"no such error"} }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := valid
+			test.modify(&p)
+
+			retryer, err := retryables.NewRetryerFromPolicy(p, nil)
+			assert.ErrorIs(t, err, retryables.ErrInvalidPolicy)
+			assert.Nil(t, retryer)
+		})
+	}
+
+	retryer, err := retryables.NewRetryerFromPolicy(valid, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, retryer)
+}
@@ -0,0 +1,202 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_Precheck(t *testing.T) {
+	errDown := errors.New("dependency down")
+
+	tests := []struct {
+		name            string
+		consumesAttempt bool
+		precheckFails   int
+		expectErr       error
+		expectPrechecks int
+		expectTries     int
+	}{
+		{
+			name:            "Passing precheck",
+			precheckFails:   0,
+			expectPrechecks: 1,
+			expectTries:     1,
+		},
+		{
+			name:            "Failed prechecks do not consume attempts",
+			precheckFails:   2,
+			expectPrechecks: 3,
+			expectTries:     1,
+		},
+		{
+			name:            "Precheck budget exhausted",
+			precheckFails:   5,
+			expectErr:       errDown,
+			expectPrechecks: 3,
+			expectTries:     0,
+		},
+		{
+			name:            "Failed prechecks consume attempts",
+			consumesAttempt: true,
+			precheckFails:   2,
+			expectPrechecks: 3,
+			expectTries:     1,
+		},
+		{
+			name:            "Consumed attempts exhausted",
+			consumesAttempt: true,
+			precheckFails:   3,
+			expectErr:       errDown,
+			expectPrechecks: 3,
+			expectTries:     0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			retryer := retryables.NewRetryer(nil)
+			retryer.SetCount(3)
+			retryer.SetClock(newFakeClock())
+			retryer.SetPrecheckConsumesAttempt(test.consumesAttempt)
+
+			prechecks := 0
+			retryer.SetPrecheck(func(ctx context.Context) error {
+				prechecks++
+				if prechecks <= test.precheckFails {
+					return errDown
+				}
+				return nil
+			})
+
+			tries := 0
+			err := retryer.Retry(context.Background(), func() error {
+				tries++
+				return nil
+			})
+
+			if test.expectErr != nil {
+				assert.ErrorIs(t, err, test.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectPrechecks, prechecks)
+			assert.Equal(t, test.expectTries, tries)
+		})
+	}
+}
+
+func TestRetryer_Precheck_BeforeEveryAttempt(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetClock(newFakeClock())
+
+	prechecks := 0
+	retryer.SetPrecheck(func(ctx context.Context) error {
+		prechecks++
+		return nil
+	})
+
+	tries := 0
+	err := retryer.Retry(context.Background(), func() error {
+		tries++
+		return errors.New("operation failed")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 3, tries)
+	assert.Equal(t, 3, prechecks)
+}
+
+func TestRetryer_Precheck_NonRetryable(t *testing.T) {
+	errFatal := errors.New("fatal")
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetConditionFunc(func(err error) bool { return !errors.Is(err, errFatal) })
+	retryer.SetPrecheck(func(ctx context.Context) error { return errFatal })
+
+	tries := 0
+	err := retryer.Retry(context.Background(), func() error {
+		tries++
+		return nil
+	})
+
+	assert.ErrorIs(t, err, errFatal)
+	assert.Equal(t, 0, tries)
+}
+
+func TestRetryer_Precheck_CallOverride(t *testing.T) {
+	errDown := errors.New("dependency down")
+	errMaintenance := errors.New("down for maintenance")
+	respond := func(status int) (*http.Response, error) {
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: http.NoBody}, nil
+	}
+	retryStatus := func(resp *http.Response, err error) bool {
+		return err != nil || resp.StatusCode >= 500
+	}
+
+	newRetryer := func(precheckErrs ...error) *retryables.Retryer {
+		retryer := retryables.NewRetryer(nil)
+		retryer.SetCount(3)
+		retryer.SetClock(newFakeClock())
+		retryer.SetConditionFunc(func(err error) bool {
+			return !errors.Is(err, errMaintenance)
+		})
+		prechecks := 0
+		retryer.SetPrecheck(func(context.Context) error {
+			prechecks++
+			if prechecks <= len(precheckErrs) {
+				return precheckErrs[prechecks-1]
+			}
+			return nil
+		})
+		return retryer
+	}
+
+	t.Run("RetryHTTP retries a retryable precheck error", func(t *testing.T) {
+		calls := 0
+		resp, err := newRetryer(errDown).RetryHTTP(context.Background(), func(context.Context) (*http.Response, error) {
+			calls++
+			return respond(http.StatusOK)
+		}, retryStatus)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("RetryHTTP stops on a non-retryable precheck error", func(t *testing.T) {
+		calls := 0
+		// the first precheck passes, the second one fails after a retryable 503
+		resp, err := newRetryer(nil, errMaintenance).RetryHTTP(context.Background(), func(context.Context) (*http.Response, error) {
+			calls++
+			return respond(http.StatusServiceUnavailable)
+		}, retryStatus)
+
+		assert.ErrorIs(t, err, errMaintenance)
+		assert.Nil(t, resp)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("RetryMeta retries a retryable precheck error", func(t *testing.T) {
+		calls := 0
+		meta, err := retryables.RetryMeta(context.Background(), newRetryer(errDown), func() (int, error) {
+			calls++
+			return 42, nil
+		}, func(int, error) (bool, time.Duration) {
+			return false, 0
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 42, meta)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,36 @@
+package retryables
+
+import (
+	"math"
+	"time"
+)
+
+// SetPressureGauge scales the backoff by a shared signal of downstream pressure, letting many retryers of
+// a process back off together. gauge returns the current pressure in [0, 1] (values outside are clamped and
+// NaN counts as none) and is read before every sleep; it must be safe for concurrent use, e.g. backed by an
+// atomic. The backoff is multiplied by 1 + pressure*(maxFactor-1), so it is unchanged without pressure and
+// maxFactor times longer at full pressure, then capped at maxDelay and jittered as usual. maxFactor values
+// below 1 are treated as 1, so pressure never shortens the backoff. Passing a nil gauge disables scaling.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetPressureGauge(gauge func() float64, maxFactor float64) {
+	r.pressureGauge = gauge
+	r.pressureMaxFactor = max(maxFactor, 1)
+}
+
+// pressureBackoff scales backoff by the pressure gauge, if any.
+func (r *Retryer) pressureBackoff(backoff, maxDelay time.Duration) time.Duration {
+	if r.pressureGauge == nil {
+		return backoff
+	}
+
+	pressure := r.pressureGauge()
+	if math.IsNaN(pressure) {
+		pressure = 0 // a broken signal, e.g. a 0/0 ratio, must not cut the backoff
+	}
+	pressure = min(max(pressure, 0), 1)
+	scaled := float64(backoff) * (1 + pressure*(r.pressureMaxFactor-1))
+	if scaled >= float64(maxDelay) {
+		return maxDelay
+	}
+	return max(time.Duration(scaled), 0)
+}
@@ -0,0 +1,66 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_PressureGauge(t *testing.T) {
+	var pressure atomic.Uint64 // float64 bits
+	gauge := func() float64 {
+		return math.Float64frombits(pressure.Load())
+	}
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetDelay(100*time.Millisecond, time.Second)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(newFakeClock())
+	retryer.SetRecordSchedule(true)
+	retryer.SetPressureGauge(gauge, 5)
+
+	tests := []struct {
+		pressure float64
+		expect   []time.Duration
+	}{
+		{pressure: 0, expect: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}},
+		{pressure: 0.5, expect: []time.Duration{300 * time.Millisecond, 600 * time.Millisecond}},
+		{pressure: 1, expect: []time.Duration{500 * time.Millisecond, time.Second}},                     // capped at maxDelay
+		{pressure: 7, expect: []time.Duration{500 * time.Millisecond, time.Second}},                     // clamped to 1
+		{pressure: math.NaN(), expect: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}}, // no pressure
+	}
+
+	for _, test := range tests {
+		pressure.Store(math.Float64bits(test.pressure))
+		_ = retryer.Retry(context.Background(), func() error {
+			return errors.New("overloaded")
+		})
+		assert.Equal(t, test.expect, retryer.LastSchedule(), "pressure %v", test.pressure)
+	}
+}
+
+func TestRetryer_PressureGaugeMaxFactorBelowOne(t *testing.T) {
+	for _, maxFactor := range []float64{0, 0.5, -3} {
+		retryer := retryables.NewRetryer(nil)
+		retryer.SetCount(3)
+		retryer.SetDelay(100*time.Millisecond, time.Second)
+		retryer.SetJitter(retryables.JitterNone)
+		retryer.SetClock(newFakeClock())
+		retryer.SetRecordSchedule(true)
+		retryer.SetPressureGauge(func() float64 { return 1 }, maxFactor)
+
+		_ = retryer.Retry(context.Background(), func() error {
+			return errors.New("overloaded")
+		})
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, retryer.LastSchedule(),
+			"max factor %v", maxFactor)
+	}
+}
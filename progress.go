@@ -0,0 +1,45 @@
+package retryables
+
+import (
+	"context"
+	"time"
+)
+
+// RetryInfo describes a failed attempt that is about to be retried.
+type RetryInfo struct {
+	Attempt int           // one-based number of the failed attempt
+	Err     error         // error of the failed attempt
+	Delay   time.Duration // sleep before the next attempt
+	Elapsed time.Duration // time since the start of the Retry call
+	// Remaining is the time left before the max elapsed budget (see SetMaxElapsed) or the stop time
+	// of RetryStopAt runs out. It is zero when neither is set.
+	Remaining time.Duration
+}
+
+// SetOnRetry sets a callback invoked after every failed attempt that is going to be retried, right before
+// the sleep. Together with SetMaxElapsed it can report progress like "retrying, 2.1s of 10s budget used".
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetOnRetry(onRetry func(RetryInfo)) {
+	r.onRetry = onRetry
+}
+
+// SetMaxElapsed bounds the total duration of a Retry call. Retry gives up and returns the last error
+// instead of sleeping when the sleep would not end before the budget runs out.
+// The attempt contexts passed by RetryCtx carry a deadline at the end of the budget (or the deadline
+// of the call context, if earlier), so the function's own I/O respects the budget too; functions that
+// ignore their context are not interrupted. Zero disables the budget.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetMaxElapsed(maxElapsed time.Duration) {
+	r.maxElapsed = maxElapsed
+}
+
+// SetOnCancel sets a hook invoked once when Retry stops because its context is done between attempts,
+// typically while sleeping, after at least one failure. It is meant for best-effort cleanup of state held
+// across attempts, such as a reserved slot or a pending transaction, and is not invoked when attempts run out
+// or a non-retryable error is returned. lastErr is the error of the latest failed attempt (nil if only
+// prechecks failed). Note that ctx is the already-done context of the call: derive the cleanup context
+// from context.WithoutCancel(ctx) or context.Background() instead.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetOnCancel(onCancel func(ctx context.Context, lastErr error)) {
+	r.onCancel = onCancel
+}
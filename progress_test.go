@@ -0,0 +1,220 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_OnRetry_Budget(t *testing.T) {
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(10)
+	retryer.SetDelay(time.Second, time.Second)
+	retryer.SetClock(clock)
+	retryer.SetMaxElapsed(10 * time.Second)
+
+	var infos []retryables.RetryInfo
+	retryer.SetOnRetry(func(info retryables.RetryInfo) {
+		infos = append(infos, info)
+	})
+
+	errTemporary := errors.New("temporary error")
+	err := retryer.Retry(context.Background(), func() error {
+		clock.Advance(2 * time.Second) // every attempt takes 2s
+		return errTemporary
+	})
+
+	assert.ErrorIs(t, err, errTemporary)
+	if assert.NotEmpty(t, infos) {
+		var sleeps time.Duration
+		for i, info := range infos {
+			expectElapsed := time.Duration(i+1)*2*time.Second + sleeps
+			assert.Equal(t, i+1, info.Attempt)
+			assert.ErrorIs(t, info.Err, errTemporary)
+			assert.Equal(t, expectElapsed, info.Elapsed)
+			assert.Equal(t, 10*time.Second-expectElapsed, info.Remaining)
+			assert.Less(t, info.Delay, info.Remaining)
+			sleeps += info.Delay
+		}
+	}
+	assert.Less(t, len(infos), 5, "the budget must stop retries before the attempt count")
+}
+
+func TestRetryer_OnRetry_NoBudget(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetClock(newFakeClock())
+
+	var infos []retryables.RetryInfo
+	retryer.SetOnRetry(func(info retryables.RetryInfo) {
+		infos = append(infos, info)
+	})
+
+	_ = retryer.Retry(context.Background(), func() error {
+		return errors.New("permanent error")
+	})
+
+	assert.Len(t, infos, 2)
+	for _, info := range infos {
+		assert.Zero(t, info.Remaining)
+	}
+}
+
+func TestRetryer_MaxElapsed(t *testing.T) {
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(100)
+	retryer.SetDelay(time.Second, time.Second)
+	retryer.SetClock(clock)
+	retryer.SetMaxElapsed(5 * time.Second)
+
+	start := clock.Now()
+	tries := 0
+	err := retryer.Retry(context.Background(), func() error {
+		tries++
+		clock.Advance(time.Second)
+		return errors.New("permanent error")
+	})
+
+	assert.Error(t, err)
+	assert.Less(t, tries, 100)
+	// the budget is checked before sleeping, the last attempt itself may run past it
+	assert.LessOrEqual(t, clock.Now().Sub(start), 5*time.Second+time.Second)
+}
+
+func TestRetryer_MaxElapsed_AttemptDeadline(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetDelay(10*time.Millisecond, 10*time.Millisecond)
+	retryer.SetMaxElapsed(time.Minute)
+
+	start := time.Now()
+	var deadlines []time.Time
+	err := retryer.RetryCtx(context.Background(), func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		deadlines = append(deadlines, deadline)
+		return errors.New("temporary error")
+	})
+	assert.Error(t, err)
+
+	if assert.Len(t, deadlines, 3) {
+		assert.WithinDuration(t, start.Add(time.Minute), deadlines[0], time.Second)
+		// the deadline is fixed, so the remaining budget shrinks from attempt to attempt
+		assert.Equal(t, deadlines[0], deadlines[1])
+		assert.Equal(t, deadlines[0], deadlines[2])
+	}
+
+	parentDeadline := time.Now().Add(time.Second)
+	parent, cancel := context.WithDeadline(context.Background(), parentDeadline)
+	defer cancel()
+
+	err = retryer.RetryCtx(parent, func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		assert.Equal(t, parentDeadline, deadline, "the earlier deadline wins")
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestRetryer_OnCancel(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(5)
+	retryer.SetDelay(time.Hour, time.Hour)
+	retryer.SetJitter(retryables.JitterNone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errTemporary := errors.New("temporary error")
+
+	calls := 0
+	var cleanupErr error
+	retryer.SetOnCancel(func(ctx context.Context, lastErr error) {
+		calls++
+		cleanupErr = lastErr
+		assert.Error(t, ctx.Err(), "context of the call is already done")
+	})
+	retryer.SetOnRetry(func(retryables.RetryInfo) {
+		cancel() // cancel as the retryer goes to sleep
+	})
+
+	err := retryer.Retry(ctx, func() error {
+		return errTemporary
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, cleanupErr, errTemporary)
+}
+
+func TestRetryer_OnCancel_NotOnExhaustion(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(2)
+	retryer.SetClock(newFakeClock())
+
+	calls := 0
+	retryer.SetOnCancel(func(context.Context, error) { calls++ })
+
+	_ = retryer.Retry(context.Background(), func() error { return errors.New("permanent error") })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = retryer.Retry(ctx, func() error { return nil })
+
+	assert.Zero(t, calls)
+}
+
+func TestRetryer_RetryStopAt(t *testing.T) {
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(100)
+	retryer.SetDelay(500*time.Millisecond, 500*time.Millisecond)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(clock)
+
+	var remaining []time.Duration
+	retryer.SetOnRetry(func(info retryables.RetryInfo) {
+		remaining = append(remaining, info.Remaining)
+	})
+
+	stop := clock.Now().Add(5 * time.Second)
+	errTemporary := errors.New("temporary error")
+	var starts []time.Time
+	err := retryer.RetryStopAt(context.Background(), stop, func() error {
+		starts = append(starts, clock.Now())
+		clock.Advance(time.Second)
+		return errTemporary
+	})
+
+	assert.ErrorIs(t, err, errTemporary)
+	// attempts run 0-1s, 1.5-2.5s, 3-4s and 4.5-5.5s, the sleep after the last one would pass the stop time
+	assert.Len(t, starts, 4)
+	assert.Equal(t, []time.Duration{4 * time.Second, 2500 * time.Millisecond, time.Second}, remaining)
+	for _, start := range starts {
+		assert.True(t, start.Before(stop))
+	}
+}
+
+func TestRetryer_RetryStopAt_MaxElapsedEarlier(t *testing.T) {
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(100)
+	retryer.SetDelay(time.Second, time.Second)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(clock)
+	retryer.SetMaxElapsed(3 * time.Second)
+
+	tries := 0
+	err := retryer.RetryStopAt(context.Background(), clock.Now().Add(time.Hour), func() error {
+		tries++
+		return errors.New("temporary error")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 3, tries)
+}
@@ -0,0 +1,102 @@
+package retryables
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RetryResult describes how a Retry call went.
+type RetryResult struct {
+	Attempts int           // number of times the function was called
+	Errors   []error       // errors of the failed attempts, in order
+	Elapsed  time.Duration // duration of the whole call
+	// ErrorsVaried reports whether the failed attempts did not all fail with the same error,
+	// as decided by the error comparator (see SetErrorComparator). It is false with fewer than two errors.
+	ErrorsVaried bool
+	// ErrorCounts tallies the errors of the failed attempts, e.g. {"timeout": 3, "connection refused": 1}.
+	// Errors are grouped by message or, if an error comparator is set, by the comparator; a group is keyed
+	// by the message of its first error. It is nil if no attempt failed.
+	ErrorCounts map[string]int
+	// TimeToSuccess is the recovery latency of the call: the time from its start until the successful
+	// attempt returned, covering the failed attempts and the sleeps before it. It is zero if the call
+	// failed or succeeded on the first attempt.
+	TimeToSuccess time.Duration
+	ExitReason    ExitReason // why the call stopped
+}
+
+// RetryWithResult is like Retry, but also returns the details of the call.
+func (r *Retryer) RetryWithResult(ctx context.Context, retryFunc RetryableFunc) (RetryResult, error) {
+	var result RetryResult
+	err := r.run(ctx, &call{
+		fn: func(context.Context) error {
+			return retryFunc()
+		},
+		result: &result,
+	})
+	return result, err
+}
+
+// SetErrorComparator sets how errors of attempts are compared when computing RetryResult.ErrorsVaried.
+// By default two errors are the same if either one matches the other with errors.Is; passing nil restores it.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetErrorComparator(comparator func(a, b error) bool) {
+	r.errorComparator = comparator
+}
+
+func (r *Retryer) result(inv *invocation, err error, elapsed time.Duration) RetryResult {
+	result := RetryResult{
+		Attempts:   inv.attempts,
+		Errors:     inv.errs,
+		Elapsed:    elapsed,
+		ExitReason: inv.exitReason,
+	}
+	if err == nil && len(inv.errs) > 0 {
+		result.TimeToSuccess = inv.lastReturn.Sub(inv.start)
+	}
+
+	same := r.errorComparator
+	if same == nil {
+		same = func(a, b error) bool {
+			return errors.Is(a, b) || errors.Is(b, a)
+		}
+	}
+	for _, err := range inv.errs[min(1, len(inv.errs)):] {
+		if !same(inv.errs[0], err) {
+			result.ErrorsVaried = true
+			break
+		}
+	}
+	result.ErrorCounts = r.countErrors(inv.errs)
+	return result
+}
+
+func (r *Retryer) countErrors(errs []error) map[string]int {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	if r.errorComparator == nil {
+		for _, err := range errs {
+			counts[err.Error()]++
+		}
+		return counts
+	}
+
+	var groups []error // first error of every group
+	for _, err := range errs {
+		group := err
+		for _, first := range groups {
+			if r.errorComparator(first, err) {
+				group = first
+				break
+			}
+		}
+		if group == err {
+			groups = append(groups, err)
+		}
+		counts[group.Error()]++
+	}
+	return counts
+}
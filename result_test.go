@@ -0,0 +1,159 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_RetryWithResult_ErrorsVaried(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	errRefused := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		comparator   func(a, b error) bool
+		expectVaried bool
+	}{
+		{
+			name:         "Same error",
+			errs:         []error{errTimeout, errTimeout, errTimeout},
+			expectVaried: false,
+		},
+		{
+			name:         "Same error wrapped",
+			errs:         []error{errTimeout, fmt.Errorf("read: %w", errTimeout)},
+			expectVaried: false,
+		},
+		{
+			name:         "Varied errors",
+			errs:         []error{errTimeout, errTimeout, errRefused},
+			expectVaried: true,
+		},
+		{
+			name:         "Single error",
+			errs:         []error{errRefused},
+			expectVaried: false,
+		},
+		{
+			name: "Comparator",
+			errs: []error{errors.New("timeout"), errors.New("timeout")},
+			comparator: func(a, b error) bool {
+				return a.Error() == b.Error()
+			},
+			expectVaried: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			retryer := retryables.NewRetryer(nil)
+			retryer.SetCount(len(test.errs))
+			retryer.SetClock(newFakeClock())
+			retryer.SetErrorComparator(test.comparator)
+
+			attempts := 0
+			result, err := retryer.RetryWithResult(context.Background(), func() error {
+				attempts++
+				return test.errs[attempts-1]
+			})
+
+			assert.ErrorIs(t, err, test.errs[len(test.errs)-1])
+			assert.Equal(t, len(test.errs), result.Attempts)
+			assert.Equal(t, test.errs, result.Errors)
+			assert.Equal(t, test.expectVaried, result.ErrorsVaried)
+		})
+	}
+}
+
+func TestRetryer_RetryWithResult_Success(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+
+	result, err := retryer.RetryWithResult(context.Background(), func() error { return nil })
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Attempts)
+	assert.Empty(t, result.Errors)
+	assert.False(t, result.ErrorsVaried)
+}
+
+func TestRetryer_RetryWithResult_ErrorCounts(t *testing.T) {
+	errs := []error{
+		errors.New("timeout"),
+		errors.New("connection refused"),
+		errors.New("timeout"),
+		fmt.Errorf("dial: %w", errors.New("connection refused")),
+		errors.New("timeout"),
+	}
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(len(errs))
+	retryer.SetClock(newFakeClock())
+
+	attempts := 0
+	fn := func() error {
+		attempts++
+		return errs[attempts-1]
+	}
+
+	result, err := retryer.RetryWithResult(context.Background(), fn)
+	assert.Error(t, err)
+	assert.Equal(t, map[string]int{"timeout": 3, "connection refused": 1, "dial: connection refused": 1}, result.ErrorCounts)
+
+	retryer.SetErrorComparator(func(a, b error) bool {
+		return strings.HasSuffix(a.Error(), b.Error()) || strings.HasSuffix(b.Error(), a.Error())
+	})
+	attempts = 0
+	result, err = retryer.RetryWithResult(context.Background(), fn)
+	assert.Error(t, err)
+	assert.Equal(t, map[string]int{"timeout": 3, "connection refused": 2}, result.ErrorCounts)
+
+	result, err = retryer.RetryWithResult(context.Background(), func() error { return nil })
+	assert.NoError(t, err)
+	assert.Nil(t, result.ErrorCounts)
+}
+
+func TestRetryer_RetryWithResult_TimeToSuccess(t *testing.T) {
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(4)
+	retryer.SetDelay(time.Second, time.Second)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(clock)
+	retryer.SetSummaryLog(true) // the summary runs after the last attempt and must not count
+
+	attempts := 0
+	result, err := retryer.RetryWithResult(context.Background(), func() error {
+		attempts++
+		clock.Advance(300 * time.Millisecond)
+		if attempts < 3 {
+			return errors.New("temporary error")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	// 3 attempts of 300ms and 2 sleeps of 1s
+	assert.Equal(t, 3*300*time.Millisecond+2*time.Second, result.TimeToSuccess)
+
+	result, err = retryer.RetryWithResult(context.Background(), func() error {
+		clock.Advance(time.Second)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, result.TimeToSuccess, "no recovery on first attempt success")
+
+	result, err = retryer.RetryWithResult(context.Background(), func() error {
+		return errors.New("permanent error")
+	})
+	assert.Error(t, err)
+	assert.Zero(t, result.TimeToSuccess)
+}
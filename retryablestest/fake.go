@@ -0,0 +1,91 @@
+// Package retryablestest provides helpers for testing code that depends on retryables.
+package retryablestest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+var _ retryables.Interface = (*FakeRetryer)(nil)
+
+// Call records a single Retry or RetryCtx invocation of a FakeRetryer.
+type Call struct {
+	Ctx     context.Context // context passed by the caller
+	FuncErr error           // error returned by the function
+	Err     error           // error returned to the caller
+}
+
+// FakeRetryer implements retryables.Interface for tests. Every invocation calls the function exactly once,
+// without any backoff, and records the call. The error returned to the caller is the next scripted result,
+// if any is queued (see SetResults), or the error of the function otherwise.
+// It is safe for concurrent use.
+type FakeRetryer struct {
+	mu      sync.Mutex
+	results []error
+	calls   []Call
+}
+
+// NewFakeRetryer returns a FakeRetryer with the given scripted results queued, see SetResults.
+func NewFakeRetryer(results ...error) *FakeRetryer {
+	f := &FakeRetryer{}
+	f.SetResults(results...)
+	return f
+}
+
+// SetResults replaces the queue of scripted results: the n-th following invocation returns results[n],
+// whatever the function returned; a nil result makes the invocation succeed. Once the queue is drained,
+// invocations return the error of the function.
+func (f *FakeRetryer) SetResults(results ...error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append([]error(nil), results...)
+}
+
+// Retry implements retryables.Interface.
+func (f *FakeRetryer) Retry(ctx context.Context, retryFunc retryables.RetryableFunc) error {
+	return f.RetryCtx(ctx, func(context.Context) error {
+		return retryFunc()
+	})
+}
+
+// RetryCtx implements retryables.Interface.
+func (f *FakeRetryer) RetryCtx(ctx context.Context, retryFunc retryables.RetryableCtxFunc) error {
+	funcErr := retryFunc(ctx)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := funcErr
+	if len(f.results) > 0 {
+		err = f.results[0]
+		f.results = f.results[1:]
+	}
+	f.calls = append(f.calls, Call{Ctx: ctx, FuncErr: funcErr, Err: err})
+	return err
+}
+
+// CallCount returns the number of invocations so far.
+func (f *FakeRetryer) CallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+// LastError returns the error returned to the caller by the latest invocation, or nil if there was none.
+func (f *FakeRetryer) LastError() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.calls) == 0 {
+		return nil
+	}
+	return f.calls[len(f.calls)-1].Err
+}
+
+// Calls returns the recorded invocations, in order.
+func (f *FakeRetryer) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
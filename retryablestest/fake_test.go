@@ -0,0 +1,65 @@
+package retryablestest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+	"github.com/llaxzi/retryables/v3/retryablestest"
+)
+
+// saveUser is code under test that depends on a retryer.
+func saveUser(ctx context.Context, r retryables.Interface, save func() error) error {
+	return r.Retry(ctx, save)
+}
+
+func TestFakeRetryer(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+	fake := retryablestest.NewFakeRetryer(errUnavailable, nil)
+
+	saves := 0
+	save := func() error {
+		saves++
+		return nil
+	}
+
+	assert.ErrorIs(t, saveUser(context.Background(), fake, save), errUnavailable)
+	assert.ErrorIs(t, fake.LastError(), errUnavailable)
+
+	assert.NoError(t, saveUser(context.Background(), fake, save))
+	assert.NoError(t, fake.LastError())
+
+	// no scripted results left, the function's own error is returned
+	errSave := errors.New("save failed")
+	assert.ErrorIs(t, saveUser(context.Background(), fake, func() error { return errSave }), errSave)
+
+	assert.Equal(t, 3, fake.CallCount())
+	assert.Equal(t, 2, saves)
+
+	calls := fake.Calls()
+	if assert.Len(t, calls, 3) {
+		assert.NoError(t, calls[0].FuncErr)
+		assert.ErrorIs(t, calls[0].Err, errUnavailable)
+		assert.ErrorIs(t, calls[2].FuncErr, errSave)
+	}
+}
+
+func TestFakeRetryer_RetryCtx(t *testing.T) {
+	fake := retryablestest.NewFakeRetryer()
+	assert.NoError(t, fake.LastError())
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	err := fake.RetryCtx(ctx, func(ctx context.Context) error {
+		assert.Equal(t, "value", ctx.Value(ctxKey{}))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fake.CallCount())
+	assert.Equal(t, ctx, fake.Calls()[0].Ctx)
+}
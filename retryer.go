@@ -2,83 +2,667 @@ package retryables
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"math/rand"
+	"strconv"
+	"sync"
 	"time"
 )
 
 type RetryableFunc func() error
 
+// RetryableCtxFunc is a RetryableFunc that receives the context of the attempt.
+type RetryableCtxFunc func(ctx context.Context) error
+
 func NewRetryer(logger io.Writer) *Retryer {
 	if logger == nil {
 		logger = io.Discard
 	}
 	return &Retryer{
-		retryCount: 3,
-		baseDelay:  time.Second,
-		maxDelay:   8 * time.Second,
+		retryCount:      3,
+		baseDelay:       time.Second,
+		maxDelay:        8 * time.Second,
+		multiplier:      2,
+		retrySampleRate: 1,
+		jitterVariance:  defaultJitterVariance,
 		retryConditionFunc: func(err error) bool {
 			return err != nil
 		},
 		logger: logger,
+		clock:  realClock{},
 	}
 }
 
+// Interface is the retry surface of Retryer. Code that only needs to run operations with retries can
+// accept it instead of *Retryer, so tests can substitute a fake such as retryablestest.FakeRetryer.
+type Interface interface {
+	Retry(ctx context.Context, retryFunc RetryableFunc) error
+	RetryCtx(ctx context.Context, retryFunc RetryableCtxFunc) error
+}
+
+var _ Interface = (*Retryer)(nil)
+
 // A Retryer provides a mechanism for retrying operations with customizable settings.
 // Warning: To ensure proper functionality, a new Retryer instance should be created whenever you need
 // different retry settings (like different conditions or delays). However, if you have multiple operations
 // that share the same retry settings, you can reuse a single Retryer instance.
+// Reusing a Retryer shares its settings, not its state: every Retry call starts its backoff from
+// baseDelay, unless the backoff is explicitly shared across calls with SetSharedBackoff.
 type Retryer struct {
 	retryConditionFunc func(error) bool
 	retryCount         int
 	baseDelay          time.Duration
 	maxDelay           time.Duration
+	multiplier         float64
+	jitterMode         Jitter
+	jitterVariance     float64
 	logger             io.Writer
+
+	statsEnabled bool
+	stats        statsAccumulator
+
+	clock Clock
+
+	rndMu sync.Mutex
+	rnd   *rand.Rand
+
+	recordSchedule bool
+	scheduleMu     sync.Mutex
+	lastSchedule   []time.Duration
+
+	precheck                func(ctx context.Context) error
+	precheckConsumesAttempt bool
+
+	idempotencyKeyFunc func() string
+	requireIdempotent  bool
+
+	maxElapsed time.Duration
+	onRetry    func(RetryInfo)
+
+	sharedBackoff bool
+	idleReset     time.Duration
+	idle          idleState
+
+	name       string
+	summaryLog bool
+
+	graceAttempts int
+
+	deadlineFit bool
+
+	errorComparator func(a, b error) bool
+
+	permanentIf func(error) bool
+
+	jsonEvents bool
+
+	retrySampleRate float64
+
+	globalRetryInterval time.Duration
+	globalBucket        leakyBucket
+
+	abortAttempt <-chan struct{}
+
+	deterministicJitter bool
+	jitterSeed          int64
+
+	escalateAfter int
+	escalated     RetryableFunc
+
+	maxSingleSleep time.Duration
+
+	cyclicDelays     []time.Duration
+	cycleWholeDelays bool
+
+	alwaysRetry []error
+
+	onCancel func(ctx context.Context, lastErr error)
+
+	pressureGauge     func() float64
+	pressureMaxFactor float64
+
+	metrics Metrics
+
+	relativeMaxDelay float64
+
+	deadlineResolver func(ctx context.Context) (time.Time, bool)
 }
 
 // Retry executes the given function with retries based on the configured settings.
 // The number of attempts is set via SetCount, and the delay between attempts increases
 // by the increment specified in SetDelay.
 func (r *Retryer) Retry(ctx context.Context, retryFunc RetryableFunc) error {
+	return r.run(ctx, &call{fn: func(context.Context) error {
+		return retryFunc()
+	}})
+}
+
+// RetryCtx is like Retry, but passes every attempt a context derived from ctx.
+// The attempt context carries the idempotency key of the call, if any (see IdempotencyKey).
+func (r *Retryer) RetryCtx(ctx context.Context, retryFunc RetryableCtxFunc) error {
+	return r.run(ctx, &call{fn: retryFunc})
+}
+
+// RetryStopAt is like Retry, but also stops retrying at the given time: it keeps making attempts until
+// success, a non-retryable error, the attempt count running out, ctx being done or stop, whichever comes
+// first. Retry never sleeps past stop; if the next sleep would reach it, the last error is returned right away.
+// The first attempt is made even if stop has already passed. It combines with SetMaxElapsed, the earlier
+// of the two limits applying.
+func (r *Retryer) RetryStopAt(ctx context.Context, stop time.Time, retryFunc RetryableFunc) error {
+	return r.run(ctx, &call{
+		fn: func(context.Context) error {
+			return retryFunc()
+		},
+		stopAt: stop,
+	})
+}
+
+// TryOnce calls retryFunc exactly once, without retrying or sleeping, and reports whether the condition
+// func considers its error retryable. It lets callers that schedule retries themselves (e.g. by
+// re-enqueueing a message) decide whether a later retry may help. If ctx is already done, retryFunc
+// is not called and ctx.Err() is returned as not retryable.
+func (r *Retryer) TryOnce(ctx context.Context, retryFunc RetryableFunc) (err error, retryable bool) {
+	if ctx.Err() != nil {
+		return ctx.Err(), false
+	}
+	err = retryFunc()
+	if err != nil && r.isPermanent(err) {
+		return unwrapPermanent(err), false
+	}
+	return err, err != nil && (r.isAlwaysRetry(err) || r.retryConditionFunc(err))
+}
+
+// call holds the parts of a retry loop that are specific to a single invocation.
+type call struct {
+	fn RetryableCtxFunc
+	// shouldRetry, when set, replaces the retryer's condition func for this invocation. Only permanent
+	// errors override it: grace attempts, always-retry errors and aborted attempts do not.
+	shouldRetry func(error) bool
+	// delay, when set and ok, replaces the backoff before the next attempt.
+	delay func(attempt int) (d time.Duration, ok bool)
+	// fields are appended to every log line of the invocation.
+	fields map[string]any
+	// result, when set, receives the details of the invocation.
+	result *RetryResult
+	// stopAt, when set, is the time no retry may be made at or after.
+	stopAt time.Time
+	// timeline, when set, receives an entry for every attempt of the invocation.
+	timeline *[]TimelineEntry
+	// hasResult tells that fn produces a result besides its error, which an escalated func can't.
+	hasResult bool
+}
+
+// run executes c and records stats for it.
+func (r *Retryer) run(ctx context.Context, c *call) error {
+	start := r.clock.Now()
+	inv := &invocation{
+		call:        c,
+		shouldRetry: r.retryConditionFunc,
+		fnCtx:       ctx,
+		start:       start,
+		fields:      formatFields(c.fields),
+		maxAttempts: max(r.retryCount, 1),
+		maxDelay:    r.maxDelay,
+	}
+	if len(r.cyclicDelays) > 0 {
+		inv.maxAttempts = unlimitedAttempts
+	}
+	if c.shouldRetry != nil {
+		inv.shouldRetry = c.shouldRetry
+	}
+
+	refused := r.Validate()
+	if r.idempotencyKeyFunc != nil {
+		inv.fnCtx = context.WithValue(ctx, idempotencyKeyCtxKey{}, r.idempotencyKeyFunc())
+	}
+
+	if r.maxElapsed > 0 {
+		var cancel context.CancelFunc
+		inv.fnCtx, cancel = context.WithTimeout(inv.fnCtx, r.maxElapsed)
+		defer cancel()
+
+		inv.stopAt = start.Add(r.maxElapsed)
+	}
+	if !c.stopAt.IsZero() {
+		inv.stopBy(c.stopAt)
+	}
+	if r.deadlineResolver != nil {
+		if deadline, ok := r.deadlineResolver(ctx); ok {
+			inv.stopBy(deadline)
+		}
+	}
+
+	if r.sharedBackoff {
+		inv.failures = r.idle.resume(start, r.idleReset)
+	}
+	if r.retrySampleRate < 1 && r.float64() >= r.retrySampleRate {
+		inv.maxAttempts = 1
+	}
+	// an unlimited loop keeps every error only if asked to report them
+	inv.keepErrs = inv.maxAttempts != unlimitedAttempts || c.result != nil || r.summaryLog
+
+	var err error
+	if refused != nil {
+		err = inv.exit(ExitNonRetryable, refused)
+	} else {
+		err = r.retry(ctx, inv)
+	}
+	if inv.cancelled && r.onCancel != nil {
+		r.onCancel(ctx, inv.lastErr())
+	}
+
+	if r.sharedBackoff && refused == nil {
+		r.idle.save(r.clock.Now(), inv.failures, err)
+	}
+	if r.recordSchedule {
+		r.scheduleMu.Lock()
+		r.lastSchedule = inv.schedule
+		r.scheduleMu.Unlock()
+	}
+	elapsed := r.clock.Now().Sub(start)
+	if r.statsEnabled {
+		r.stats.record(inv.attempts, err, elapsed)
+	}
+	if r.summaryLog {
+		r.logSummary(inv, err, elapsed)
+	}
+	if r.jsonEvents {
+		if err != nil {
+			r.emit(inv, event{Event: eventGiveUp, Attempt: inv.attempts, Err: err, Elapsed: elapsed})
+		} else {
+			r.emit(inv, event{Event: eventSuccess, Attempt: inv.attempts, Elapsed: elapsed})
+		}
+	}
+	if r.metrics != nil {
+		r.metrics.IncExit(inv.exitReason)
+	}
+	if c.result != nil {
+		*c.result = r.result(inv, err, elapsed)
+	}
+	if c.timeline != nil {
+		*c.timeline = inv.timeline
+	}
+	return err
+}
+
+// invocation is the state of a single run of the retry loop.
+type invocation struct {
+	call           *call
+	shouldRetry    func(error) bool
+	maxAttempts    int
+	fnCtx          context.Context // context passed to call.fn
+	start          time.Time
+	stopAt         time.Time     // no sleep may end at or after it, zero if unbounded
+	nextTimeout    time.Duration // timeout of the next attempt suggested by the previous one
+	attempts       int           // calls of call.fn
+	failures       int           // failed attempts and prechecks so far, drives the backoff growth
+	schedule       []time.Duration
+	errs           []error       // errors of the failed attempts, just the latest one unless keepErrs
+	keepErrs       bool          // whether errs keeps every error
+	failed         int           // failed attempts
+	fields         string        // call.fields rendered for log lines
+	busy           time.Duration // total time spent in call.fn
+	lastReturn     time.Time     // when the latest attempt returned
+	aborted        bool          // whether the latest attempt was aborted by the abort signal
+	cancelled      bool          // whether the loop stopped on ctx between attempts
+	exitReason     ExitReason    // why the loop stopped
+	maxDelay       time.Duration // cap of the backoff, see SetRelativeMaxDelay
+	timeline       []TimelineEntry
+	pendingBackoff bool // whether the latest timeline entry awaits its backoff
+}
+
+// unlimitedAttempts is the attempt count of invocations that retry until success.
+const unlimitedAttempts = math.MaxInt
+
+// attemptLabel renders the zero-based attempt for log lines, e.g. "2/3", or just "2" without a limit.
+func (inv *invocation) attemptLabel(attempt int) string {
+	if inv.maxAttempts == unlimitedAttempts {
+		return strconv.Itoa(attempt + 1)
+	}
+	return fmt.Sprintf("%d/%d", attempt+1, inv.maxAttempts)
+}
+
+// lastErr returns the error of the latest failed attempt, if any.
+func (inv *invocation) lastErr() error {
+	if len(inv.errs) == 0 {
+		return nil
+	}
+	return inv.errs[len(inv.errs)-1]
+}
+
+// stopBy moves the stop time to t if it is earlier.
+func (inv *invocation) stopBy(t time.Time) {
+	if inv.stopAt.IsZero() || t.Before(inv.stopAt) {
+		inv.stopAt = t
+	}
+}
+
+// exit records why the loop stopped and returns err.
+func (inv *invocation) exit(reason ExitReason, err error) error {
+	inv.exitReason = reason
+	return err
+}
+
+// retry runs the retry loop.
+func (r *Retryer) retry(ctx context.Context, inv *invocation) error {
 	var err error
-	for attempt := 0; attempt < r.retryCount; attempt++ {
+	precheckFailures := 0
+	for attempt := 0; attempt < inv.maxAttempts; attempt++ {
 		if ctx.Err() != nil {
-			return ctx.Err()
+			inv.cancelled = err != nil
+			return inv.exit(ExitContextCancelled, ctx.Err())
+		}
+
+		if r.precheck != nil {
+			if err = r.precheck(ctx); err != nil {
+				if !r.precheckRetryable(err) {
+					return inv.exit(ExitNonRetryable, unwrapPermanent(err))
+				}
+
+				r.logf(inv, "Precheck before attempt %s failed: %v", inv.attemptLabel(attempt), err)
+				r.emit(inv, event{Event: eventPrecheckFailure, Attempt: attempt + 1, Err: err})
+
+				if r.precheckConsumesAttempt {
+					if attempt == inv.maxAttempts-1 {
+						return inv.exit(ExitExhausted, err)
+					}
+				} else {
+					precheckFailures++
+					if precheckFailures == inv.maxAttempts {
+						return inv.exit(ExitExhausted, err)
+					}
+				}
+
+				if werr := r.wait(ctx, inv, attempt, err); werr != nil {
+					return werr
+				}
+				if !r.precheckConsumesAttempt {
+					attempt-- // the attempt has not been spent yet
+				}
+				continue
+			}
+			precheckFailures = 0
 		}
 
-		err = retryFunc()
+		inv.attempts++
+		r.emit(inv, event{Event: eventAttempt, Attempt: attempt + 1})
+		err = r.attempt(inv)
 		if err == nil {
-			return nil
+			return inv.exit(ExitSuccess, nil)
 		}
-		if !r.retryConditionFunc(err) {
-			return err
+		r.emit(inv, event{Event: eventFailure, Attempt: attempt + 1, Err: err})
+		if !r.retryable(inv, err) {
+			return inv.exit(ExitNonRetryable, unwrapPermanent(err))
 		}
 
-		_, _ = fmt.Fprintf(r.logger, "Attempt %d/%d failed: %v\n", attempt+1, r.retryCount, err)
+		r.logf(inv, "Attempt %s failed: %v", inv.attemptLabel(attempt), err)
 
-		if attempt == r.retryCount-1 {
-			return err
+		if attempt == inv.maxAttempts-1 {
+			return inv.exit(ExitExhausted, err)
 		}
 
-		backoff := r.baseDelay * time.Duration(math.Pow(2, float64(attempt)))
-		backoff = min(backoff, r.maxDelay)
+		if werr := r.wait(ctx, inv, attempt, err); werr != nil {
+			return werr
+		}
+	}
+	return inv.exit(ExitExhausted, err)
+}
+
+// retryable reports whether err, returned by the latest attempt of inv, should be retried.
+// precheckRetryable reports whether a failed precheck is retried. It follows the rules of the retryer even
+// when the call overrides them (RetryHTTP, RetryMeta), as the override judges the results of the call.
+func (r *Retryer) precheckRetryable(err error) bool {
+	if r.isPermanent(err) {
+		return false
+	}
+	return r.isAlwaysRetry(err) || r.retryConditionFunc(err)
+}
+
+func (r *Retryer) retryable(inv *invocation, err error) bool {
+	if r.isPermanent(err) {
+		return false
+	}
+	if inv.call.shouldRetry != nil {
+		return inv.call.shouldRetry(err) // the call decides alone
+	}
+	if inv.aborted || inv.failed <= r.graceAttempts || r.isAlwaysRetry(err) {
+		return true
+	}
+	return inv.shouldRetry(err)
+}
 
-		jitter := time.Duration(rand.Int63n(int64(backoff)))
+// logf writes a line to the logger, followed by the fields of inv.
+// Plain lines are not written by retryers emitting JSON events.
+func (r *Retryer) logf(inv *invocation, format string, args ...any) {
+	if r.jsonEvents {
+		return
+	}
+	_, _ = io.WriteString(r.logger, fmt.Sprintf(format, args...)+inv.fields+"\n")
+}
 
-		//time.Sleep(jitter)
+// attempt makes a single call of inv.call.fn.
+func (r *Retryer) attempt(inv *invocation) error {
+	ctx := inv.fnCtx
+	if inv.nextTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, inv.nextTimeout)
+		defer cancel()
+	}
+	var stop func() bool
+	if r.abortAttempt != nil {
+		drain(r.abortAttempt)
+		ctx, stop = abortable(ctx, r.abortAttempt)
+		defer stop()
+	}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(jitter):
+	fn := inv.call.fn
+	if r.escalated != nil && !inv.call.hasResult && inv.failed >= r.escalateAfter {
+		fn = func(context.Context) error {
+			return r.escalated()
 		}
+	}
 
+	started := r.clock.Now()
+	err := fn(ctx)
+	if stop != nil {
+		// the abort only counts if it cancelled the attempt before fn returned with the cancellation
+		inv.aborted = stop() && errors.Is(err, context.Canceled)
 	}
+	inv.lastReturn = r.clock.Now()
+	inv.busy += inv.lastReturn.Sub(started)
+	if latency := inv.lastReturn.Sub(started); r.relativeMaxDelay > 0 && inv.attempts == 1 && latency > 0 {
+		inv.maxDelay = time.Duration(float64(latency) * r.relativeMaxDelay)
+	}
+	if err != nil {
+		inv.failed++
+		if inv.keepErrs || len(inv.errs) == 0 {
+			inv.errs = append(inv.errs, err)
+		} else {
+			inv.errs[0] = err
+		}
+	}
+	if inv.call.timeline != nil {
+		inv.recordAttempt(started, err)
+	}
+	inv.nextTimeout = suggestedTimeout(err)
 	return err
 }
 
+// wait sleeps for the delay that follows the failure err of the given attempt, or until ctx is done.
+// It returns a non-nil error if the loop must stop instead of making another attempt:
+// ctx.Err() if ctx is done, or err itself if the stop time of inv does not allow for another attempt.
+func (r *Retryer) wait(ctx context.Context, inv *invocation, attempt int, err error) error {
+	jitter := r.delay(ctx, inv, attempt)
+
+	now := r.clock.Now()
+	elapsed := now.Sub(inv.start)
+	var remaining time.Duration
+	if !inv.stopAt.IsZero() {
+		remaining = max(inv.stopAt.Sub(now), 0)
+		if jitter >= remaining {
+			return inv.exit(ExitBudgetExhausted, err)
+		}
+	}
+	var reserved booking
+	if r.globalRetryInterval > 0 {
+		var ok bool
+		if reserved, ok = r.globalBucket.reserve(now, jitter, r.globalRetryInterval, inv.stopAt); !ok {
+			return inv.exit(ExitBudgetExhausted, err)
+		}
+		jitter = reserved.slot.Sub(now)
+	}
+
+	inv.failures++
+	if r.recordSchedule {
+		inv.schedule = append(inv.schedule, jitter)
+	}
+	inv.recordBackoff(jitter)
+	r.emit(inv, event{Event: eventBackoff, Attempt: attempt + 1, Delay: jitter})
+	if r.onRetry != nil {
+		r.onRetry(RetryInfo{
+			Attempt:   attempt + 1,
+			Err:       err,
+			Delay:     jitter,
+			Elapsed:   elapsed,
+			Remaining: remaining,
+		})
+	}
+
+	select {
+	case <-ctx.Done():
+		if r.globalRetryInterval > 0 {
+			r.globalBucket.release(reserved, r.globalRetryInterval)
+		}
+		inv.cancelled = true
+		return inv.exit(ExitContextCancelled, ctx.Err())
+	case <-r.clock.After(jitter):
+		return nil
+	}
+}
+
+// delay picks the sleep that follows the given failed attempt of inv.
+func (r *Retryer) delay(ctx context.Context, inv *invocation, attempt int) time.Duration {
+	d := r.uncappedDelay(ctx, inv, attempt)
+	if r.maxSingleSleep > 0 {
+		d = min(d, r.maxSingleSleep)
+	}
+	return d
+}
+
+func (r *Retryer) uncappedDelay(ctx context.Context, inv *invocation, attempt int) time.Duration {
+	if d, ok := r.callDelay(inv.call, inv.failures); ok {
+		return d
+	}
+	if len(r.cyclicDelays) > 0 {
+		return r.cyclicDelay(inv.failures)
+	}
+	if r.deadlineFit {
+		if d, ok := r.fitDelay(ctx, inv, attempt); ok {
+			return d
+		}
+	}
+	backoff := r.pressureBackoff(r.backoff(inv.failures, inv.maxDelay), inv.maxDelay)
+	return r.jitter(backoff, inv.failures, inv.maxDelay)
+}
+
+// ComputeBackoff returns the backoff before jitter that follows the given zero-based failed attempt:
+// baseDelay multiplied by the multiplier for every attempt, capped at maxDelay.
+// With the default multiplier of 2 it is computed with integer shifts guarded against overflow;
+// other multipliers are applied step by step, never letting a step decrease the delay. Either way
+// the sequence never decreases with the attempt number.
+func (r *Retryer) ComputeBackoff(attempt int) time.Duration {
+	return r.backoff(attempt, r.maxDelay)
+}
+
+// backoff is ComputeBackoff capped at maxDelay instead of the configured one.
+func (r *Retryer) backoff(attempt int, maxDelay time.Duration) time.Duration {
+	attempt = max(attempt, 0)
+	if r.multiplier != 2 {
+		return r.computeBackoffSteps(attempt, maxDelay)
+	}
+	if attempt >= 63 || r.baseDelay > math.MaxInt64>>attempt {
+		return maxDelay // baseDelay<<attempt overflows, so it is above any cap
+	}
+	return min(r.baseDelay<<attempt, maxDelay)
+}
+
+func (r *Retryer) computeBackoffSteps(attempt int, maxDelay time.Duration) time.Duration {
+	backoff := min(r.baseDelay, maxDelay)
+	for i := 0; i < attempt; i++ {
+		next := float64(backoff) * r.multiplier
+		if next >= float64(maxDelay) {
+			return maxDelay
+		}
+		if time.Duration(next) <= backoff {
+			break // the multiplier can't grow the delay any further
+		}
+		backoff = time.Duration(next)
+	}
+	return backoff
+}
+
+// jitter picks the actual sleep for the backoff of the given attempt according to the jitter mode.
+func (r *Retryer) jitter(backoff time.Duration, attempt int, maxDelay time.Duration) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+
+	switch r.jitterMode {
+	case JitterNone:
+		return backoff
+	case JitterBounded:
+		spread := (2*r.jitterFloat64(attempt) - 1) * r.jitterVariance
+		sleep := float64(backoff) * (1 + spread)
+		if sleep >= float64(maxDelay) {
+			return maxDelay
+		}
+		return max(time.Duration(sleep), 0)
+	default:
+		if r.deterministicJitter {
+			return time.Duration(rand.New(rand.NewSource(r.jitterSeed + int64(attempt))).Int63n(int64(backoff)))
+		}
+		return time.Duration(r.int63n(int64(backoff)))
+	}
+}
+
+// jitterFloat64 returns a random number in [0, 1) for the jitter of the given attempt.
+func (r *Retryer) jitterFloat64(attempt int) float64 {
+	if r.deterministicJitter {
+		return rand.New(rand.NewSource(r.jitterSeed + int64(attempt))).Float64()
+	}
+	return r.float64()
+}
+
+// int63n returns a random number in [0, n) from the generator of the retryer.
+func (r *Retryer) int63n(n int64) int64 {
+	if r.rnd == nil {
+		return rand.Int63n(n)
+	}
+	r.rndMu.Lock()
+	defer r.rndMu.Unlock()
+	return r.rnd.Int63n(n)
+}
+
+// float64 returns a random number in [0, 1) from the generator of the retryer.
+func (r *Retryer) float64() float64 {
+	if r.rnd == nil {
+		return rand.Float64()
+	}
+	r.rndMu.Lock()
+	defer r.rndMu.Unlock()
+	return r.rnd.Float64()
+}
+
+func (r *Retryer) callDelay(c *call, attempt int) (time.Duration, bool) {
+	if c.delay == nil {
+		return 0, false
+	}
+	return c.delay(attempt)
+}
+
 // SetConditionFunc sets the condition function used to determine if an error should trigger a retry.
 // This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
 func (r *Retryer) SetConditionFunc(retryConditionFunc func(error) bool) {
@@ -86,6 +670,8 @@ func (r *Retryer) SetConditionFunc(retryConditionFunc func(error) bool) {
 }
 
 // SetCount sets the number of attempts made by Retry() method.
+// Retry always makes at least one attempt (unless the context is already done), so a zero or negative
+// count does not silently skip the operation.
 // This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
 func (r *Retryer) SetCount(retryCount int) {
 	r.retryCount = retryCount
@@ -97,3 +683,122 @@ func (r *Retryer) SetDelay(baseDelay, maxDelay time.Duration) {
 	r.baseDelay = baseDelay
 	r.maxDelay = maxDelay
 }
+
+// SetStatsEnabled turns on aggregation of Retry calls, available through Stats.
+// Accumulation is disabled by default so that retryers which do not need stats pay nothing for them.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetStatsEnabled(enabled bool) {
+	r.statsEnabled = enabled
+}
+
+// SetRand sets the generator jitter is drawn from. A seeded generator (rand.New(rand.NewSource(seed)))
+// together with a fake Clock (see SetClock) makes the timing of Retry fully deterministic.
+// Passing nil restores the global math/rand source.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetRand(rnd *rand.Rand) {
+	r.rnd = rnd
+}
+
+// SetRecordSchedule enables recording of the sleeps chosen between attempts, available through LastSchedule.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetRecordSchedule(enabled bool) {
+	r.recordSchedule = enabled
+}
+
+// LastSchedule returns the sleeps, in order, made by the most recently finished Retry call
+// while SetRecordSchedule(true) was in effect. With concurrent calls, the last one to finish wins.
+func (r *Retryer) LastSchedule() []time.Duration {
+	r.scheduleMu.Lock()
+	defer r.scheduleMu.Unlock()
+	return append([]time.Duration(nil), r.lastSchedule...)
+}
+
+// SetPrecheck sets a cheap check run before every attempt, e.g. a ping of a dependency the operation needs.
+// If the precheck fails with an error the condition func considers retryable, Retry backs off and runs the
+// precheck again instead of the function; a non-retryable precheck error is returned right away.
+// Precheck errors are judged by the retryer (permanent and always-retry errors, then the condition func)
+// even under RetryHTTP and RetryMeta, whose own decision only applies to the results of their calls.
+// Once the precheck passes, the function is called.
+//
+// By default a failed precheck does not consume an attempt: the function still gets the full attempt count,
+// and the prechecks before each attempt get a budget of the same size of consecutive failures.
+// See SetPrecheckConsumesAttempt to count failed prechecks as attempts instead.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetPrecheck(precheck func(ctx context.Context) error) {
+	r.precheck = precheck
+}
+
+// SetPrecheckConsumesAttempt makes every failed precheck count as one of the attempts set via SetCount.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetPrecheckConsumesAttempt(consumes bool) {
+	r.precheckConsumesAttempt = consumes
+}
+
+// SetGraceAttempts makes the first graceAttempts failures of every Retry call retried whatever the error,
+// bypassing the condition func; later failures are retried only if the condition func allows it.
+// It does not apply to RetryMeta and RetryHTTP, whose callers decide every retry themselves.
+// It absorbs one-off glitches that the condition func would classify as permanent. The default is 0.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetGraceAttempts(graceAttempts int) {
+	r.graceAttempts = graceAttempts
+}
+
+// SetMultiplier sets the factor the backoff grows by after every failed attempt. The default is 2;
+// values below 1 are treated as 1, i.e. a constant backoff.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetMultiplier(multiplier float64) {
+	r.multiplier = max(multiplier, 1)
+}
+
+// SetJitter sets how the actual sleep is derived from the backoff. The default is JitterFull.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetJitter(jitter Jitter) {
+	r.jitterMode = jitter
+}
+
+// SetRetrySampleRate sets the probability that a Retry call actually retries. With probability 1-rate
+// a call makes a single attempt and returns its result, which sheds retry load during broad outages
+// while still retrying a sample of the calls. The decision is drawn from the generator set via SetRand.
+// The default of 1 always retries.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetRetrySampleRate(rate float64) {
+	r.retrySampleRate = rate
+}
+
+// SetDeterministicJitter derives the jitter of every attempt from seed and the attempt number, so the same
+// (seed, attempt) pair always yields the same sleep, across calls and runs, while sleeps still vary between
+// attempts. It takes precedence over the generator set via SetRand and helps reproducing timing issues.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetDeterministicJitter(seed int64) {
+	r.deterministicJitter = true
+	r.jitterSeed = seed
+}
+
+// SetEscalateAfter makes Retry switch to escalated, e.g. a simpler or more conservative operation,
+// once the function has failed k times: the remaining attempts of the call go to escalated.
+// Errors of both functions go through the same condition func. Passing a nil escalated disables escalation.
+// Calls whose function returns a result besides the error (RetryHTTP, RetryMeta, RetryWithData and
+// RetryOrDefault) are never escalated, as escalated could not produce that result.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetEscalateAfter(k int, escalated RetryableFunc) {
+	r.escalateAfter = k
+	r.escalated = escalated
+}
+
+// SetMaxSingleSleep caps every sleep between attempts, after jitter and whatever picked it (backoff,
+// Retry-After, deadline fit...), to maxSingleSleep. Unlike maxDelay, which caps the backoff before jitter,
+// it bounds the longest pause a caller may observe on any one retry. Only the global retry rate
+// (see SetGlobalRetryRate) may extend a sleep beyond it. Zero disables the cap.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetMaxSingleSleep(maxSingleSleep time.Duration) {
+	r.maxSingleSleep = maxSingleSleep
+}
+
+// SetRelativeMaxDelay expresses maxDelay relative to the cost of the operation: once the first attempt
+// of a Retry call returns, the backoff of that call is capped at its latency times multiplier instead
+// of the maxDelay set with SetDelay, which still applies until then and if the first attempt took no time.
+// The relative cap may be above or below the configured one. Zero disables it.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetRelativeMaxDelay(multiplier float64) {
+	r.relativeMaxDelay = multiplier
+}
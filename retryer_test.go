@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"math/rand"
+	"net/http"
 	"syscall"
 	"testing"
 	"time"
@@ -50,7 +52,8 @@ func someFunc(count int) (int, error) {
 }
 
 func TestRetryer_Retry(t *testing.T) {
-	excededCtx, _ := context.WithDeadline(context.Background(), time.Now())
+	excededCtx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
 
 	tests := []struct {
 		name        string
@@ -210,3 +213,190 @@ func TestRetryer_Retry_Logs(t *testing.T) {
 	assert.Contains(t, logOutput, "Attempt 2/3 failed")
 
 }
+
+func TestRetryer_Retry_GraceAttempts(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(5)
+	retryer.SetDelay(time.Millisecond, time.Millisecond)
+	retryer.SetGraceAttempts(1)
+
+	retryableErr := errors.New("retryable error")
+	otherErr := errors.New("any other error")
+
+	retryer.SetConditionFunc(func(err error) bool {
+		return errors.Is(err, retryableErr)
+	})
+
+	attempts := 0
+	err := retryer.Retry(context.Background(), func() error {
+		attempts++
+		return otherErr
+	})
+	assert.Equal(t, 2, attempts) // one grace retry, then the condition func stops it
+	assert.ErrorIs(t, err, otherErr)
+}
+
+func TestRetryer_TryOnce(t *testing.T) {
+	retryableErr := errors.New("retryable error")
+	otherErr := errors.New("any other error")
+
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetConditionFunc(func(err error) bool {
+		return errors.Is(err, retryableErr)
+	})
+
+	tests := []struct {
+		name            string
+		err             error
+		expectRetryable bool
+	}{
+		{name: "Success", err: nil, expectRetryable: false},
+		{name: "Retryable error", err: retryableErr, expectRetryable: true},
+		{name: "Other error", err: otherErr, expectRetryable: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			err, retryable := retryer.TryOnce(context.Background(), func() error {
+				attempts++
+				return test.err
+			})
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.expectRetryable, retryable)
+			assert.Equal(t, 1, attempts)
+		})
+	}
+}
+
+func TestRetryer_Retry_SampleRate(t *testing.T) {
+	const calls = 2000
+
+	for _, rate := range []float64{0, 0.3, 1} {
+		retryer := retryables.NewRetryer(nil)
+		retryer.SetCount(2)
+		retryer.SetDelay(0, 0)
+		retryer.SetRand(rand.New(rand.NewSource(1)))
+		retryer.SetRetrySampleRate(rate)
+
+		retried := 0
+		for i := 0; i < calls; i++ {
+			attempts := 0
+			_ = retryer.Retry(context.Background(), func() error {
+				attempts++
+				return errors.New("some error")
+			})
+			if attempts > 1 {
+				retried++
+			}
+		}
+
+		// 5 standard deviations of the binomial distribution
+		delta := 5 * math.Sqrt(calls*rate*(1-rate))
+		assert.InDelta(t, rate*calls, retried, delta, "rate %v", rate)
+	}
+}
+
+func TestRetryer_Retry_EscalateAfter(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(5)
+	retryer.SetDelay(time.Millisecond, time.Millisecond)
+
+	escalatedCalls := 0
+	retryer.SetEscalateAfter(2, func() error {
+		escalatedCalls++
+		if escalatedCalls < 2 {
+			return errors.New("escalated error")
+		}
+		return nil
+	})
+
+	primaryCalls := 0
+	err := retryer.Retry(context.Background(), func() error {
+		primaryCalls++
+		return errors.New("primary error")
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, primaryCalls)
+	assert.Equal(t, 2, escalatedCalls)
+}
+
+func TestRetryer_EscalateAfter_CallsWithResult(t *testing.T) {
+	newRetryer := func(escalatedCalls *int) *retryables.Retryer {
+		retryer := retryables.NewRetryer(nil)
+		retryer.SetCount(3)
+		retryer.SetClock(newFakeClock())
+		retryer.SetEscalateAfter(1, func() error {
+			*escalatedCalls++
+			return nil
+		})
+		return retryer
+	}
+	errUnavailable := errors.New("unavailable")
+
+	t.Run("RetryHTTP", func(t *testing.T) {
+		escalatedCalls, calls := 0, 0
+		resp, err := newRetryer(&escalatedCalls).RetryHTTP(context.Background(), func(context.Context) (*http.Response, error) {
+			calls++
+			status := http.StatusServiceUnavailable
+			if calls == 3 {
+				status = http.StatusOK
+			}
+			return &http.Response{StatusCode: status, Header: http.Header{}, Body: http.NoBody}, nil
+		}, func(resp *http.Response, err error) bool {
+			return err != nil || resp.StatusCode >= 500
+		})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+		assert.Equal(t, 3, calls)
+		assert.Zero(t, escalatedCalls)
+	})
+
+	t.Run("RetryMeta", func(t *testing.T) {
+		escalatedCalls, calls := 0, 0
+		meta, err := retryables.RetryMeta(context.Background(), newRetryer(&escalatedCalls), func() (int, error) {
+			calls++
+			return calls, errUnavailable
+		}, func(int, error) (bool, time.Duration) {
+			return true, 0
+		})
+
+		assert.ErrorIs(t, err, errUnavailable)
+		assert.Equal(t, 3, meta)
+		assert.Zero(t, escalatedCalls)
+	})
+
+	t.Run("RetryWithData", func(t *testing.T) {
+		escalatedCalls, calls := 0, 0
+		data, err := retryables.RetryWithData(context.Background(), newRetryer(&escalatedCalls), func() (string, error) {
+			calls++
+			if calls < 2 {
+				return "", errUnavailable
+			}
+			return "value", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "value", data)
+		assert.Zero(t, escalatedCalls)
+	})
+}
+
+func TestRetryer_Retry_AtLeastOneAttempt(t *testing.T) {
+	for _, count := range []int{0, -1} {
+		retryer := retryables.NewRetryer(nil)
+		retryer.SetCount(count)
+
+		retryableErr := errors.New("retryable error")
+		attempts := 0
+		err := retryer.Retry(context.Background(), func() error {
+			attempts++
+			return retryableErr
+		})
+		assert.ErrorIs(t, err, retryableErr, "count %d", count)
+		assert.Equal(t, 1, attempts, "count %d", count)
+	}
+}
@@ -0,0 +1,72 @@
+package retryables
+
+import (
+	"sync"
+	"time"
+)
+
+// RetryerStats is an aggregate view of the Retry calls made while stats were enabled.
+type RetryerStats struct {
+	Calls       int64         // number of Retry calls
+	Successes   int64         // calls that returned nil
+	GiveUps     int64         // calls that returned an error
+	AvgAttempts float64       // average number of attempts per call
+	MinElapsed  time.Duration // shortest call
+	MaxElapsed  time.Duration // longest call
+	AvgElapsed  time.Duration // average call duration
+}
+
+type statsAccumulator struct {
+	mu           sync.Mutex
+	calls        int64
+	successes    int64
+	giveUps      int64
+	attempts     int64
+	minElapsed   time.Duration
+	maxElapsed   time.Duration
+	totalElapsed time.Duration
+}
+
+func (s *statsAccumulator) record(attempts int, err error, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.calls == 0 || elapsed < s.minElapsed {
+		s.minElapsed = elapsed
+	}
+	if elapsed > s.maxElapsed {
+		s.maxElapsed = elapsed
+	}
+	s.calls++
+	if err == nil {
+		s.successes++
+	} else {
+		s.giveUps++
+	}
+	s.attempts += int64(attempts)
+	s.totalElapsed += elapsed
+}
+
+func (s *statsAccumulator) snapshot() RetryerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := RetryerStats{
+		Calls:      s.calls,
+		Successes:  s.successes,
+		GiveUps:    s.giveUps,
+		MinElapsed: s.minElapsed,
+		MaxElapsed: s.maxElapsed,
+	}
+	if s.calls > 0 {
+		stats.AvgAttempts = float64(s.attempts) / float64(s.calls)
+		stats.AvgElapsed = s.totalElapsed / time.Duration(s.calls)
+	}
+	return stats
+}
+
+// Stats returns the metrics accumulated since SetStatsEnabled(true) was called.
+// It is safe to call concurrently with Retry.
+func (r *Retryer) Stats() RetryerStats {
+	return r.stats.snapshot()
+}
@@ -0,0 +1,71 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_Stats(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(3)
+	retryer.SetDelay(time.Millisecond, time.Millisecond)
+	retryer.SetStatsEnabled(true)
+
+	// 1 attempt, success
+	_ = retryer.Retry(context.Background(), func() error { return nil })
+
+	// 3 attempts, give up
+	_ = retryer.Retry(context.Background(), func() error { return errors.New("permanent error") })
+
+	// 2 attempts, success
+	attempts := 0
+	_ = retryer.Retry(context.Background(), func() error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("temporary error")
+		}
+		return nil
+	})
+
+	stats := retryer.Stats()
+	assert.Equal(t, int64(3), stats.Calls)
+	assert.Equal(t, int64(2), stats.Successes)
+	assert.Equal(t, int64(1), stats.GiveUps)
+	assert.InDelta(t, 2.0, stats.AvgAttempts, 0.001)
+	assert.LessOrEqual(t, stats.MinElapsed, stats.AvgElapsed)
+	assert.LessOrEqual(t, stats.AvgElapsed, stats.MaxElapsed)
+}
+
+func TestRetryer_Stats_Concurrent(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetStatsEnabled(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = retryer.Retry(context.Background(), func() error { return nil })
+		}()
+	}
+	wg.Wait()
+
+	stats := retryer.Stats()
+	assert.Equal(t, int64(50), stats.Calls)
+	assert.Equal(t, int64(50), stats.Successes)
+	assert.InDelta(t, 1.0, stats.AvgAttempts, 0.001)
+}
+
+func TestRetryer_Stats_Disabled(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	_ = retryer.Retry(context.Background(), func() error { return nil })
+
+	assert.Equal(t, retryables.RetryerStats{}, retryer.Stats())
+}
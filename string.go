@@ -0,0 +1,113 @@
+package retryables
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String describes the policy of the retryer, e.g.
+//
+//	Retryer(attempts=3, base=1s, max=8s, multiplier=2.0, jitter=full)
+//
+// Optional features are listed after the base settings, in a fixed order, only when configured,
+// so the output is stable enough to be logged at startup and compared. The attempt count is the effective
+// one: at least 1, and "unlimited" with cyclic delays. Function-valued settings are
+// shown as configured (e.g. precheck=true) and error lists by their length (always_retry=2).
+// Deliberately left out are the condition func, which every retryer has, and the observers that don't
+// change which retries are made or how they are logged: the callbacks of SetOnRetry and SetOnCancel,
+// SetMetrics, SetStatsEnabled, SetRecordSchedule, SetErrorComparator, SetClock and SetRand.
+func (r *Retryer) String() string {
+	var b strings.Builder
+	b.WriteString("Retryer(")
+	if r.name != "" {
+		_, _ = fmt.Fprintf(&b, "name=%q, ", r.name)
+	}
+	attempts := strconv.Itoa(max(r.retryCount, 1))
+	if len(r.cyclicDelays) > 0 {
+		attempts = "unlimited"
+	}
+	_, _ = fmt.Fprintf(&b, "attempts=%s, base=%s, max=%s, multiplier=%s, jitter=%s",
+		attempts, r.baseDelay, r.maxDelay, formatMultiplier(r.multiplier), r.jitterMode)
+
+	if r.jitterMode == JitterBounded {
+		_, _ = fmt.Fprintf(&b, ", jitter_variance=%s", strconv.FormatFloat(r.jitterVariance, 'f', -1, 64))
+	}
+	if r.deterministicJitter {
+		_, _ = fmt.Fprintf(&b, ", jitter_seed=%d", r.jitterSeed)
+	}
+	if len(r.cyclicDelays) > 0 {
+		_, _ = fmt.Fprintf(&b, ", cyclic_delays=%v, cycle_whole=%t", r.cyclicDelays, r.cycleWholeDelays)
+	}
+	if r.deadlineFit {
+		b.WriteString(", deadline_fit=true")
+	}
+	if r.deadlineResolver != nil {
+		b.WriteString(", deadline_resolver=true")
+	}
+	if r.maxElapsed > 0 {
+		_, _ = fmt.Fprintf(&b, ", max_elapsed=%s", r.maxElapsed)
+	}
+	if r.maxSingleSleep > 0 {
+		_, _ = fmt.Fprintf(&b, ", max_single_sleep=%s", r.maxSingleSleep)
+	}
+	if r.relativeMaxDelay > 0 {
+		_, _ = fmt.Fprintf(&b, ", relative_max=%s", formatMultiplier(r.relativeMaxDelay))
+	}
+	if r.sharedBackoff {
+		b.WriteString(", shared_backoff=true")
+		if r.idleReset > 0 {
+			_, _ = fmt.Fprintf(&b, ", idle_reset=%s", r.idleReset)
+		}
+	}
+	if r.graceAttempts > 0 {
+		_, _ = fmt.Fprintf(&b, ", grace=%d", r.graceAttempts)
+	}
+	if len(r.alwaysRetry) > 0 {
+		_, _ = fmt.Fprintf(&b, ", always_retry=%d", len(r.alwaysRetry))
+	}
+	if r.permanentIf != nil {
+		b.WriteString(", permanent_if=true")
+	}
+	if r.escalated != nil {
+		_, _ = fmt.Fprintf(&b, ", escalate_after=%d", r.escalateAfter)
+	}
+	if r.abortAttempt != nil {
+		b.WriteString(", abort_signal=true")
+	}
+	if r.retrySampleRate < 1 {
+		_, _ = fmt.Fprintf(&b, ", sample_rate=%s", strconv.FormatFloat(r.retrySampleRate, 'f', -1, 64))
+	}
+	if r.pressureGauge != nil {
+		_, _ = fmt.Fprintf(&b, ", pressure_max_factor=%s", formatMultiplier(r.pressureMaxFactor))
+	}
+	if r.globalRetryInterval > 0 {
+		_, _ = fmt.Fprintf(&b, ", global_retry_interval=%s", r.globalRetryInterval)
+	}
+	if r.precheck != nil {
+		_, _ = fmt.Fprintf(&b, ", precheck=true, precheck_consumes_attempt=%t", r.precheckConsumesAttempt)
+	}
+	if r.idempotencyKeyFunc != nil {
+		b.WriteString(", idempotency_key=true")
+	}
+	if r.requireIdempotent {
+		b.WriteString(", require_idempotent=true")
+	}
+	if r.summaryLog {
+		b.WriteString(", summary_log=true")
+	}
+	if r.jsonEvents {
+		b.WriteString(", json_events=true")
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// formatMultiplier formats m with as many digits as needed, but at least one decimal.
+func formatMultiplier(m float64) string {
+	s := strconv.FormatFloat(m, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
@@ -0,0 +1,102 @@
+package retryables_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_String(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(r *retryables.Retryer)
+		expect    string
+	}{
+		{
+			name:      "Default",
+			configure: func(r *retryables.Retryer) {},
+			expect:    "Retryer(attempts=3, base=1s, max=8s, multiplier=2.0, jitter=full)",
+		},
+		{
+			name: "Custom backoff",
+			configure: func(r *retryables.Retryer) {
+				r.SetCount(5)
+				r.SetDelay(100*time.Millisecond, 2*time.Second)
+				r.SetMultiplier(1.5)
+				r.SetJitter(retryables.JitterNone)
+			},
+			expect: "Retryer(attempts=5, base=100ms, max=2s, multiplier=1.5, jitter=none)",
+		},
+		{
+			name: "Zero count",
+			configure: func(r *retryables.Retryer) {
+				r.SetCount(0)
+			},
+			expect: "Retryer(attempts=1, base=1s, max=8s, multiplier=2.0, jitter=full)",
+		},
+		{
+			name: "Optional features",
+			configure: func(r *retryables.Retryer) {
+				r.SetName("fetch")
+				r.SetMaxElapsed(10 * time.Second)
+				r.SetRelativeMaxDelay(4)
+				r.SetGraceAttempts(1)
+				r.SetRetrySampleRate(0.25)
+				r.SetPrecheck(func(ctx context.Context) error { return nil })
+			},
+			expect: `Retryer(name="fetch", attempts=3, base=1s, max=8s, multiplier=2.0, jitter=full, ` +
+				`max_elapsed=10s, relative_max=4.0, grace=1, sample_rate=0.25, precheck=true, precheck_consumes_attempt=false)`,
+		},
+		{
+			name: "Cyclic delays",
+			configure: func(r *retryables.Retryer) {
+				r.SetCyclicDelays([]time.Duration{time.Second, 5 * time.Second}, true)
+			},
+			expect: "Retryer(attempts=unlimited, base=1s, max=8s, multiplier=2.0, jitter=full, cyclic_delays=[1s 5s], cycle_whole=true)",
+		},
+		{
+			name: "Classification and output",
+			configure: func(r *retryables.Retryer) {
+				r.SetAlwaysRetry(context.DeadlineExceeded, context.Canceled)
+				r.SetPermanentIf(func(err error) bool { return false })
+				r.SetAbortAttemptSignal(make(chan struct{}))
+				r.SetIdempotencyKeyFunc(func() string { return "key" })
+				r.SetSummaryLog(true)
+			},
+			expect: "Retryer(attempts=3, base=1s, max=8s, multiplier=2.0, jitter=full, always_retry=2, " +
+				"permanent_if=true, abort_signal=true, idempotency_key=true, summary_log=true)",
+		},
+		{
+			name: "Shared backoff",
+			configure: func(r *retryables.Retryer) {
+				r.SetSharedBackoff(true)
+				r.SetIdleReset(time.Minute)
+			},
+			expect: "Retryer(attempts=3, base=1s, max=8s, multiplier=2.0, jitter=full, shared_backoff=true, idle_reset=1m0s)",
+		},
+		{
+			name: "Deadline resolver",
+			configure: func(r *retryables.Retryer) {
+				r.SetDeadlineResolver(func(ctx context.Context) (time.Time, bool) { return ctx.Deadline() })
+			},
+			expect: "Retryer(attempts=3, base=1s, max=8s, multiplier=2.0, jitter=full, deadline_resolver=true)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			retryer := retryables.NewRetryer(nil)
+			test.configure(retryer)
+			assert.Equal(t, test.expect, retryer.String())
+		})
+	}
+}
+
+func TestRetryer_StringJSONEvents(t *testing.T) {
+	retryer := retryables.NewJSONRetryer(nil)
+	assert.Equal(t, "Retryer(attempts=3, base=1s, max=8s, multiplier=2.0, jitter=full, json_events=true)", retryer.String())
+}
@@ -0,0 +1,54 @@
+package retryables
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetName sets the name of the operation the retryer is used for. It is included in the summary log.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetName(name string) {
+	r.name = name
+}
+
+// SetSummaryLog enables a single summary line written to the logger at the end of every Retry call, e.g.
+//
+//	operation fetch: 3 attempts, 2 failures (timeout; connection refused), 1.4s elapsed, succeeded
+//
+// The distinct errors of the failed attempts are listed in the order they were first seen.
+// This method is intended for initialization and is not thread-safe if modified dynamically at runtime.
+func (r *Retryer) SetSummaryLog(enabled bool) {
+	r.summaryLog = enabled
+}
+
+func (r *Retryer) logSummary(inv *invocation, err error, elapsed time.Duration) {
+	var b strings.Builder
+	b.WriteString("operation")
+	if r.name != "" {
+		b.WriteString(" ")
+		b.WriteString(r.name)
+	}
+	_, _ = fmt.Fprintf(&b, ": %d attempts, %d failures", inv.attempts, len(inv.errs))
+
+	if len(inv.errs) > 0 {
+		seen := make(map[string]bool, len(inv.errs))
+		distinct := make([]string, 0, len(inv.errs))
+		for _, attemptErr := range inv.errs {
+			msg := attemptErr.Error()
+			if !seen[msg] {
+				seen[msg] = true
+				distinct = append(distinct, msg)
+			}
+		}
+		_, _ = fmt.Fprintf(&b, " (%s)", strings.Join(distinct, "; "))
+	}
+
+	outcome := "succeeded"
+	if err != nil {
+		outcome = "failed: " + err.Error()
+	}
+	_, _ = fmt.Fprintf(&b, ", %s elapsed, %s", elapsed, outcome)
+
+	r.logf(inv, "%s", b.String())
+}
@@ -0,0 +1,70 @@
+package retryables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_SummaryLog(t *testing.T) {
+	var logBuffer bytes.Buffer
+
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(&logBuffer)
+	retryer.SetCount(5)
+	retryer.SetDelay(100*time.Millisecond, 100*time.Millisecond)
+	retryer.SetClock(clock)
+	retryer.SetRecordSchedule(true)
+	retryer.SetName("fetch")
+	retryer.SetSummaryLog(true)
+
+	errs := []error{errors.New("timeout"), errors.New("connection refused"), errors.New("timeout")}
+	attempts := 0
+	err := retryer.Retry(context.Background(), func() error {
+		clock.Advance(200 * time.Millisecond)
+		attempts++
+		if attempts <= len(errs) {
+			return errs[attempts-1]
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	elapsed := 4 * 200 * time.Millisecond
+	for _, sleep := range retryer.LastSchedule() {
+		elapsed += sleep
+	}
+	expect := "operation fetch: 4 attempts, 3 failures (timeout; connection refused), " + elapsed.String() + " elapsed, succeeded\n"
+	assert.Contains(t, logBuffer.String(), expect)
+}
+
+func TestRetryer_SummaryLog_Failed(t *testing.T) {
+	var logBuffer bytes.Buffer
+
+	retryer := retryables.NewRetryer(&logBuffer)
+	retryer.SetCount(2)
+	retryer.SetDelay(0, 0)
+	retryer.SetClock(newFakeClock())
+	retryer.SetSummaryLog(true)
+
+	_ = retryer.Retry(context.Background(), func() error {
+		return errors.New("permanent error")
+	})
+
+	assert.Contains(t, logBuffer.String(), "operation: 2 attempts, 2 failures (permanent error), 0s elapsed, failed: permanent error\n")
+}
+
+func TestRetryer_SummaryLog_Disabled(t *testing.T) {
+	var logBuffer bytes.Buffer
+
+	retryer := retryables.NewRetryer(&logBuffer)
+	_ = retryer.Retry(context.Background(), func() error { return nil })
+
+	assert.Empty(t, logBuffer.String())
+}
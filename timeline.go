@@ -0,0 +1,48 @@
+package retryables
+
+import (
+	"context"
+	"time"
+)
+
+// TimelineEntry records one attempt of a Retry call.
+type TimelineEntry struct {
+	Attempt      int           // one-based number of the attempt
+	StartedAt    time.Time     // when the attempt started, as told by the clock (see SetClock)
+	Duration     time.Duration // how long the attempt took
+	Err          error         // error of the attempt, nil if it succeeded
+	BackoffAfter time.Duration // sleep scheduled after the attempt, zero if none followed it
+}
+
+// RetryWithTimeline is like Retry, but also returns an entry for every attempt, in order, combining
+// its timing with the backoff that followed it. It suits audit trails and Gantt-style views of a call.
+// Sleeps after failed prechecks (see SetPrecheck) are not attributed to any attempt.
+func (r *Retryer) RetryWithTimeline(ctx context.Context, retryFunc RetryableFunc) ([]TimelineEntry, error) {
+	var timeline []TimelineEntry
+	err := r.run(ctx, &call{
+		fn: func(context.Context) error {
+			return retryFunc()
+		},
+		timeline: &timeline,
+	})
+	return timeline, err
+}
+
+// recordAttempt appends the attempt that started at started and just returned err to the timeline.
+func (inv *invocation) recordAttempt(started time.Time, err error) {
+	inv.timeline = append(inv.timeline, TimelineEntry{
+		Attempt:   inv.attempts,
+		StartedAt: started,
+		Duration:  inv.lastReturn.Sub(started),
+		Err:       err,
+	})
+	inv.pendingBackoff = true
+}
+
+// recordBackoff sets the sleep that follows the latest attempt, if it is not set yet.
+func (inv *invocation) recordBackoff(sleep time.Duration) {
+	if inv.pendingBackoff {
+		inv.timeline[len(inv.timeline)-1].BackoffAfter = sleep
+		inv.pendingBackoff = false
+	}
+}
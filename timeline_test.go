@@ -0,0 +1,86 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryWithTimeline(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(5)
+	retryer.SetDelay(100*time.Millisecond, time.Second)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(clock)
+
+	errUnavailable := errors.New("unavailable")
+	calls := 0
+	timeline, err := retryer.RetryWithTimeline(context.Background(), func() error {
+		calls++
+		clock.Advance(time.Duration(calls) * 10 * time.Millisecond)
+		if calls < 3 {
+			return errUnavailable
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []retryables.TimelineEntry{
+		{
+			Attempt:      1,
+			StartedAt:    start,
+			Duration:     10 * time.Millisecond,
+			Err:          errUnavailable,
+			BackoffAfter: 100 * time.Millisecond,
+		},
+		{
+			Attempt:      2,
+			StartedAt:    start.Add(110 * time.Millisecond),
+			Duration:     20 * time.Millisecond,
+			Err:          errUnavailable,
+			BackoffAfter: 200 * time.Millisecond,
+		},
+		{
+			Attempt:   3,
+			StartedAt: start.Add(330 * time.Millisecond),
+			Duration:  30 * time.Millisecond,
+		},
+	}, timeline)
+}
+
+func TestRetryWithTimeline_Precheck(t *testing.T) {
+	clock := newFakeClock()
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(2)
+	retryer.SetDelay(100*time.Millisecond, time.Second)
+	retryer.SetJitter(retryables.JitterNone)
+	retryer.SetClock(clock)
+
+	prechecks := 0
+	retryer.SetPrecheck(func(context.Context) error {
+		prechecks++
+		if prechecks == 2 {
+			return errors.New("dependency down")
+		}
+		return nil
+	})
+
+	timeline, err := retryer.RetryWithTimeline(context.Background(), func() error {
+		return errors.New("unavailable")
+	})
+
+	assert.Error(t, err)
+	if assert.Len(t, timeline, 2) {
+		// the sleep after the failed precheck is not attributed to the first attempt
+		assert.Equal(t, 100*time.Millisecond, timeline[0].BackoffAfter)
+		assert.Equal(t, time.Duration(0), timeline[1].BackoffAfter)
+		assert.Equal(t, 2, timeline[1].Attempt)
+	}
+}
@@ -0,0 +1,40 @@
+package retryables
+
+import (
+	"errors"
+	"time"
+)
+
+// attemptTimeoutError carries the timeout suggested for the attempt that follows a failure.
+type attemptTimeoutError struct {
+	err     error
+	timeout time.Duration
+}
+
+func (e *attemptTimeoutError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attemptTimeoutError) Unwrap() error {
+	return e.err
+}
+
+// WithNextAttemptTimeout annotates the error of a failed attempt with the timeout the next attempt may take,
+// e.g. as told by the server. RetryCtx passes the next attempt a context with that timeout.
+// The suggestion only applies to the next attempt; an attempt failing without one runs the following attempt
+// with the context of the call as is. The annotated error behaves like err for errors.Is, errors.As and logging.
+// It returns nil if err is nil.
+func WithNextAttemptTimeout(err error, timeout time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &attemptTimeoutError{err: err, timeout: timeout}
+}
+
+func suggestedTimeout(err error) time.Duration {
+	var timeoutErr *attemptTimeoutError
+	if errors.As(err, &timeoutErr) {
+		return timeoutErr.timeout
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package retryables_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llaxzi/retryables/v3"
+)
+
+func TestRetryer_WithNextAttemptTimeout(t *testing.T) {
+	retryer := retryables.NewRetryer(nil)
+	retryer.SetCount(4)
+	retryer.SetClock(newFakeClock())
+
+	errBusy := errors.New("server busy")
+	suggested := []time.Duration{time.Minute, time.Hour, 0}
+
+	var timeouts []time.Duration
+	err := retryer.RetryCtx(context.Background(), func(ctx context.Context) error {
+		var timeout time.Duration
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		}
+		timeouts = append(timeouts, timeout)
+
+		if len(timeouts) > len(suggested) {
+			return nil
+		}
+		return retryables.WithNextAttemptTimeout(errBusy, suggested[len(timeouts)-1])
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, timeouts, 4) {
+		assert.Zero(t, timeouts[0], "first attempt has no suggested timeout")
+		assert.InDelta(t, time.Minute, timeouts[1], float64(time.Second))
+		assert.InDelta(t, time.Hour, timeouts[2], float64(time.Second))
+		assert.Zero(t, timeouts[3], "suggestion applies only to the next attempt")
+	}
+}
+
+func TestWithNextAttemptTimeout_Unwrap(t *testing.T) {
+	errBusy := errors.New("server busy")
+	err := retryables.WithNextAttemptTimeout(errBusy, time.Second)
+
+	assert.ErrorIs(t, err, errBusy)
+	assert.Equal(t, errBusy.Error(), err.Error())
+	assert.NoError(t, retryables.WithNextAttemptTimeout(nil, time.Second))
+}